@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"engine/graphics"
+	"flag"
+	"fmt"
+	"image/png"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/golang/freetype/truetype"
+)
+
+func check(err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func parseRanges(str string) ([]rune, error) {
+	runes := []rune{}
+	seen := map[rune]bool{}
+	for _, part := range strings.Split(str, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		bounds := strings.SplitN(part, "-", 2)
+		start, err := strconv.ParseInt(bounds[0], 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid character range '%s'", part)
+		}
+		end := start
+		if len(bounds) == 2 {
+			end, err = strconv.ParseInt(bounds[1], 0, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid character range '%s'", part)
+			}
+		}
+		if end < start {
+			return nil, fmt.Errorf("invalid character range '%s'", part)
+		}
+		for r := rune(start); r <= rune(end); r++ {
+			if !seen[r] {
+				seen[r] = true
+				runes = append(runes, r)
+			}
+		}
+	}
+	return runes, nil
+}
+
+func main() {
+	ttfPath := flag.String("ttf", "", "TrueType font to bake")
+	size := flag.Int("size", 96, "font size in pixels")
+	ranges := flag.String("ranges", "32-126", "comma separated character ranges, e.g. 32-126,0xA0-0xFF")
+	padding := flag.Int("padding", 6, "distance field spread around each glyph in pixels")
+	width := flag.Int("width", 1024, "atlas width in pixels")
+	out := flag.String("out", "font", "output path without extension")
+	flag.Parse()
+
+	if *ttfPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	runes, err := parseRanges(*ranges)
+	check(err)
+
+	ttfData, err := os.ReadFile(*ttfPath)
+	check(err)
+	ttf, err := truetype.Parse(ttfData)
+	check(err)
+
+	data, atlas := graphics.GenerateFont(ttf, *size, runes, *padding, *width)
+
+	pngFile, err := os.Create(*out + ".png")
+	check(err)
+	defer pngFile.Close()
+	check(png.Encode(pngFile, atlas))
+
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	check(err)
+	check(os.WriteFile(*out+".json", jsonData, 0644))
+}
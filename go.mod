@@ -5,7 +5,7 @@ go 1.16
 require (
 	github.com/go-gl/gl v0.0.0-20210501111010-69f74958bac0
 	github.com/go-gl/glfw v0.0.0-20210410170116-ea3d685f79fb
-	github.com/go-gl/mathgl v1.0.0 // indirect
-	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0 // indirect
-	golang.org/x/image v0.0.0-20210504121937-7319ad40d33e // indirect
+	github.com/go-gl/mathgl v1.0.0
+	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0
+	golang.org/x/image v0.0.0-20210504121937-7319ad40d33e
 )
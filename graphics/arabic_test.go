@@ -0,0 +1,38 @@
+package graphics
+
+import "testing"
+
+func TestShapeArabic(t *testing.T) {
+	all := func(rune) bool { return true }
+	tests := []struct {
+		name    string
+		text    string
+		has     func(rune) bool
+		shaped  []rune
+		ligated []bool
+	}{
+		{"isolated", "ب", all, []rune{0xFE8F}, []bool{false}},
+		{"initial and final", "بب", all, []rune{0xFE91, 0xFE90}, []bool{false, false}},
+		{"medial", "ببب", all, []rune{0xFE91, 0xFE92, 0xFE90}, []bool{false, false, false}},
+		{"right joining", "باب", all, []rune{0xFE91, 0xFE8E, 0xFE8F}, []bool{false, false, false}},
+		{"transparent mark", "بَب", all, []rune{0xFE91, 0x064E, 0xFE90}, []bool{false, false, false}},
+		{"separated by a space", "ب ب", all, []rune{0xFE8F, ' ', 0xFE8F}, []bool{false, false, false}},
+		{"lam-alef", "لا", all, []rune{0xFEFB, 0x0627}, []bool{false, true}},
+		{"final lam-alef", "بلاب", all, []rune{0xFE91, 0xFEFC, 0x0627, 0xFE8F}, []bool{false, false, true, false}},
+		{"lam-alef missing from the font", "لا", func(r rune) bool { return r != 0xFEFB }, []rune{0xFEDF, 0xFE8E}, []bool{false, false}},
+		{"missing form", "بب", func(r rune) bool { return r != 0xFE91 }, []rune{0x0628, 0xFE90}, []bool{false, false}},
+		{"latin", "ab", all, []rune{'a', 'b'}, []bool{false, false}},
+	}
+	for _, test := range tests {
+		shaped, ligated := shapeArabic([]rune(test.text), test.has)
+		if string(shaped) != string(test.shaped) {
+			t.Errorf("%s: got %U, want %U", test.name, shaped, test.shaped)
+		}
+		for i := range ligated {
+			if ligated[i] != test.ligated[i] {
+				t.Errorf("%s: got ligated %v, want %v", test.name, ligated, test.ligated)
+				break
+			}
+		}
+	}
+}
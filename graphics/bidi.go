@@ -0,0 +1,295 @@
+package graphics
+
+import "unicode"
+
+type TextDirection int
+
+const (
+	DirectionAuto TextDirection = iota
+	DirectionLTR
+	DirectionRTL
+)
+
+type bidiClass int
+
+const (
+	bidiL bidiClass = iota
+	bidiR
+	bidiAL
+	bidiEN
+	bidiES
+	bidiET
+	bidiAN
+	bidiCS
+	bidiNSM
+	bidiBN
+	bidiB
+	bidiS
+	bidiWS
+	bidiON
+)
+
+var bidiMirrors = map[rune]rune{
+	'(': ')', ')': '(',
+	'[': ']', ']': '[',
+	'{': '}', '}': '{',
+	'<': '>', '>': '<',
+	'«': '»', '»': '«',
+	'‹': '›', '›': '‹',
+}
+
+func classifyBidi(r rune) bidiClass {
+	switch {
+	case r == '\n' || r == '\r' || r == 0x2029:
+		return bidiB
+	case r == '\t' || r == 0x1F:
+		return bidiS
+	case r == 0x200E:
+		return bidiL
+	case r == 0x200F:
+		return bidiR
+	case r == 0x061C:
+		return bidiAL
+	case r == 0x200C || r == 0x200D || r == 0xFEFF:
+		return bidiBN
+	case r >= '0' && r <= '9', r >= 0x06F0 && r <= 0x06F9:
+		return bidiEN
+	case r >= 0x0660 && r <= 0x0669, r == 0x066B, r == 0x066C:
+		return bidiAN
+	case r == '+' || r == '-':
+		return bidiES
+	case r == '#' || r == '$' || r == '%' || r == 0x00B0 || r == 0x066A || unicode.Is(unicode.Sc, r):
+		return bidiET
+	case r == ',' || r == '.' || r == '/' || r == ':' || r == 0x00A0 || r == 0x060C:
+		return bidiCS
+	case unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r):
+		return bidiNSM
+	case unicode.IsSpace(r):
+		return bidiWS
+	case r >= 0x0590 && r <= 0x05FF, r >= 0x07C0 && r <= 0x085F, r >= 0xFB1D && r <= 0xFB4F, r >= 0x10800 && r <= 0x10FFF:
+		return bidiR
+	case r >= 0x0600 && r <= 0x07BF, r >= 0x0860 && r <= 0x08FF, r >= 0xFB50 && r <= 0xFDFF, r >= 0xFE70 && r <= 0xFEFE:
+		return bidiAL
+	case unicode.IsControl(r):
+		return bidiBN
+	case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mc, r):
+		return bidiL
+	}
+	return bidiON
+}
+
+func isStrongRTL(class bidiClass) bool {
+	return class == bidiR || class == bidiAL
+}
+
+func isNeutral(class bidiClass) bool {
+	return class == bidiON || class == bidiWS || class == bidiS || class == bidiB || class == bidiBN
+}
+
+func paragraphLevel(classes []bidiClass, direction TextDirection) int {
+	switch direction {
+	case DirectionLTR:
+		return 0
+	case DirectionRTL:
+		return 1
+	}
+	for _, class := range classes {
+		if class == bidiL {
+			return 0
+		}
+		if isStrongRTL(class) {
+			return 1
+		}
+	}
+	return 0
+}
+
+func resolveLevels(classes []bidiClass, base int) []int {
+	n := len(classes)
+	types := append([]bidiClass{}, classes...)
+	embedding := bidiL
+	if base%2 == 1 {
+		embedding = bidiR
+	}
+
+	for i := range types {
+		if types[i] == bidiNSM {
+			if i == 0 {
+				types[i] = embedding
+			} else {
+				types[i] = types[i-1]
+			}
+		}
+	}
+
+	strong := embedding
+	for i, t := range types {
+		switch t {
+		case bidiL, bidiR, bidiAL:
+			strong = t
+		case bidiEN:
+			if strong == bidiAL {
+				types[i] = bidiAN
+			}
+		}
+	}
+
+	for i, t := range types {
+		if t == bidiAL {
+			types[i] = bidiR
+		}
+	}
+
+	for i := 1; i+1 < n; i++ {
+		prev, next := types[i-1], types[i+1]
+		switch types[i] {
+		case bidiES:
+			if prev == bidiEN && next == bidiEN {
+				types[i] = bidiEN
+			}
+		case bidiCS:
+			if prev == next && (prev == bidiEN || prev == bidiAN) {
+				types[i] = prev
+			}
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		if types[i] != bidiET {
+			continue
+		}
+		end := i
+		for end < n && types[end] == bidiET {
+			end++
+		}
+		if (i > 0 && types[i-1] == bidiEN) || (end < n && types[end] == bidiEN) {
+			for j := i; j < end; j++ {
+				types[j] = bidiEN
+			}
+		}
+		i = end - 1
+	}
+
+	for i, t := range types {
+		if t == bidiES || t == bidiET || t == bidiCS {
+			types[i] = bidiON
+		}
+	}
+
+	strong = embedding
+	for i, t := range types {
+		switch t {
+		case bidiL, bidiR:
+			strong = t
+		case bidiEN:
+			if strong == bidiL {
+				types[i] = bidiL
+			}
+		}
+	}
+
+	direction := func(t bidiClass) bidiClass {
+		if t == bidiEN || t == bidiAN {
+			return bidiR
+		}
+		return t
+	}
+	for i := 0; i < n; i++ {
+		if !isNeutral(types[i]) {
+			continue
+		}
+		end := i
+		for end < n && isNeutral(types[end]) {
+			end++
+		}
+		before, after := embedding, embedding
+		if i > 0 {
+			before = direction(types[i-1])
+		}
+		if end < n {
+			after = direction(types[end])
+		}
+		resolved := embedding
+		if before == after {
+			resolved = before
+		}
+		for j := i; j < end; j++ {
+			types[j] = resolved
+		}
+		i = end - 1
+	}
+
+	levels := make([]int, n)
+	for i, t := range types {
+		level := base
+		if base%2 == 0 {
+			switch t {
+			case bidiR:
+				level++
+			case bidiAN, bidiEN:
+				level += 2
+			}
+		} else if t == bidiL || t == bidiEN || t == bidiAN {
+			level++
+		}
+		levels[i] = level
+	}
+
+	for i, class := range classes {
+		if class == bidiS || class == bidiB {
+			levels[i] = base
+			for j := i - 1; j >= 0 && (classes[j] == bidiWS || classes[j] == bidiBN); j-- {
+				levels[j] = base
+			}
+		}
+	}
+
+	return levels
+}
+
+func lineLevels(levels []int, classes []bidiClass, start, end, base int) []int {
+	line := append([]int{}, levels[start:end]...)
+	for i := end - 1; i >= start; i-- {
+		class := classes[i]
+		if class != bidiWS && class != bidiS && class != bidiB && class != bidiBN {
+			break
+		}
+		line[i-start] = base
+	}
+	return line
+}
+
+func visualOrder(levels []int) []int {
+	order := make([]int, len(levels))
+	for i := range order {
+		order[i] = i
+	}
+
+	highest, lowestOdd := 0, 1<<30
+	for _, level := range levels {
+		if level > highest {
+			highest = level
+		}
+		if level%2 == 1 && level < lowestOdd {
+			lowestOdd = level
+		}
+	}
+
+	for level := highest; level >= lowestOdd; level-- {
+		for i := 0; i < len(order); i++ {
+			if levels[order[i]] < level {
+				continue
+			}
+			end := i
+			for end < len(order) && levels[order[end]] >= level {
+				end++
+			}
+			for a, b := i, end-1; a < b; a, b = a+1, b-1 {
+				order[a], order[b] = order[b], order[a]
+			}
+			i = end
+		}
+	}
+
+	return order
+}
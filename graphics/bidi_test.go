@@ -0,0 +1,80 @@
+package graphics
+
+import "testing"
+
+func TestBidiLevels(t *testing.T) {
+	tests := []struct {
+		text      string
+		direction TextDirection
+		base      int
+		levels    []int
+		order     []int
+	}{
+		{"abc", DirectionAuto, 0, []int{0, 0, 0}, []int{0, 1, 2}},
+		{"אבג", DirectionAuto, 1, []int{1, 1, 1}, []int{2, 1, 0}},
+		{"אב", DirectionLTR, 0, []int{1, 1}, []int{1, 0}},
+		{"ab", DirectionRTL, 1, []int{2, 2}, []int{0, 1}},
+		{"ab אב 12", DirectionAuto, 0, []int{0, 0, 0, 1, 1, 1, 2, 2}, []int{0, 1, 2, 6, 7, 5, 4, 3}},
+		{"אב 12 גד", DirectionAuto, 1, []int{1, 1, 1, 2, 2, 1, 1, 1}, []int{7, 6, 5, 3, 4, 2, 1, 0}},
+		{"אב 12.5", DirectionAuto, 1, []int{1, 1, 1, 2, 2, 2, 2}, []int{3, 4, 5, 6, 2, 1, 0}},
+		{"אב -12%", DirectionAuto, 1, []int{1, 1, 1, 1, 2, 2, 2}, []int{4, 5, 6, 3, 2, 1, 0}},
+		{"אב ab 12", DirectionAuto, 1, []int{1, 1, 1, 2, 2, 2, 2, 2}, []int{3, 4, 5, 6, 7, 2, 1, 0}},
+		{"a (אב) b", DirectionAuto, 0, []int{0, 0, 0, 1, 1, 0, 0, 0}, []int{0, 1, 2, 4, 3, 5, 6, 7}},
+		{"12 ab", DirectionAuto, 0, []int{0, 0, 0, 0, 0}, []int{0, 1, 2, 3, 4}},
+	}
+	for _, test := range tests {
+		_, levels, bases := bidiLevels([]rune(test.text), test.direction)
+		if bases[0] != test.base {
+			t.Errorf("%q: got base %d, want %d", test.text, bases[0], test.base)
+		}
+		if !equalInts(levels, test.levels) {
+			t.Errorf("%q: got levels %v, want %v", test.text, levels, test.levels)
+		}
+		if order := visualOrder(levels); !equalInts(order, test.order) {
+			t.Errorf("%q: got order %v, want %v", test.text, order, test.order)
+		}
+	}
+}
+
+func TestBidiParagraphs(t *testing.T) {
+	_, levels, bases := bidiLevels([]rune("אב\nab"), DirectionAuto)
+	if !equalInts(bases, []int{1, 1, 1, 0, 0}) || !equalInts(levels, []int{1, 1, 1, 0, 0}) {
+		t.Errorf("got bases %v and levels %v, want each paragraph to pick its own direction", bases, levels)
+	}
+}
+
+func TestLineLevelsResetTrailingWhitespace(t *testing.T) {
+	classes, levels, _ := bidiLevels([]rune("ab אב "), DirectionAuto)
+	if line := lineLevels(levels, classes, 0, 6, 0); !equalInts(line, []int{0, 0, 0, 1, 1, 0}) {
+		t.Errorf("got line levels %v, want the trailing space at the base level", line)
+	}
+}
+
+func TestBidiMirroredBrackets(t *testing.T) {
+	layout := MeasureString(testBidiFontData(), "(אב)", DefaultLayoutOptions)
+	widths := []float32{4, 10, 10, 3}
+	lefts := []float32{23, 13, 3, 0}
+	for i := range widths {
+		glyph := layout.Glyphs[i]
+		if glyph.Min.X() != lefts[i] || glyph.Max.X()-glyph.Min.X() != widths[i] {
+			t.Errorf("glyph %d spans %v-%v, want %v wide from %v", i, glyph.Min.X(), glyph.Max.X(), widths[i], lefts[i])
+		}
+	}
+
+	ltr := MeasureString(testBidiFontData(), "a (b)", DefaultLayoutOptions)
+	if width := ltr.Glyphs[2].Max.X() - ltr.Glyphs[2].Min.X(); width != 3 {
+		t.Errorf("LTR opening bracket is %v wide, want 3", width)
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
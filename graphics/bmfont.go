@@ -0,0 +1,306 @@
+package graphics
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+type bmRecord struct {
+	tag   string
+	attrs map[string]string
+}
+
+func LoadBMFont(fsys fs.FS, name string) (Font, error) {
+	fnt, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return Font{}, err
+	}
+	fontData, err := ParseBMFont(fnt)
+	if err != nil {
+		return Font{}, err
+	}
+
+	font := Font{data: fontData}
+	for _, page := range fontData.Pages {
+		pageData, err := fs.ReadFile(fsys, path.Join(path.Dir(name), page))
+		if err != nil {
+			font.Delete()
+			return Font{}, err
+		}
+		texture, err := TextureFromPNG(pageData)
+		if err != nil {
+			font.Delete()
+			return Font{}, fmt.Errorf("bmfont page '%s': %v", page, err)
+		}
+		texture.SetFilter(gl.NEAREST)
+		font.textures = append(font.textures, texture)
+	}
+	return font, nil
+}
+
+func ParseBMFont(data []byte) (FontData, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte("BMF")):
+		return parseBMFontBinary(data)
+	case bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")):
+		records, err := parseBMFontXML(data)
+		if err != nil {
+			return FontData{}, err
+		}
+		return bmRecordsToFontData(records)
+	default:
+		records, err := parseBMFontText(data)
+		if err != nil {
+			return FontData{}, err
+		}
+		return bmRecordsToFontData(records)
+	}
+}
+
+func parseBMFontText(data []byte) ([]bmRecord, error) {
+	records := []bmRecord{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		tag := line
+		rest := ""
+		if i := strings.IndexAny(line, " \t"); i >= 0 {
+			tag, rest = line[:i], line[i+1:]
+		}
+		attrs := map[string]string{}
+		for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimSpace(rest) {
+			eq := strings.IndexByte(rest, '=')
+			if eq < 0 {
+				return nil, fmt.Errorf("bmfont line %d: expected key=value", lineNumber)
+			}
+			key := rest[:eq]
+			rest = rest[eq+1:]
+			value := ""
+			if strings.HasPrefix(rest, "\"") {
+				end := strings.IndexByte(rest[1:], '"')
+				if end < 0 {
+					return nil, fmt.Errorf("bmfont line %d: unterminated string", lineNumber)
+				}
+				value, rest = rest[1:end+1], rest[end+2:]
+			} else if end := strings.IndexAny(rest, " \t"); end >= 0 {
+				value, rest = rest[:end], rest[end:]
+			} else {
+				value, rest = rest, ""
+			}
+			attrs[key] = value
+		}
+		records = append(records, bmRecord{tag, attrs})
+	}
+	return records, scanner.Err()
+}
+
+func parseBMFontXML(data []byte) ([]bmRecord, error) {
+	records := []bmRecord{}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if element, ok := token.(xml.StartElement); ok {
+			attrs := map[string]string{}
+			for _, attr := range element.Attr {
+				attrs[attr.Name.Local] = attr.Value
+			}
+			records = append(records, bmRecord{element.Name.Local, attrs})
+		}
+	}
+}
+
+func bmRecordsToFontData(records []bmRecord) (FontData, error) {
+	fontData := FontData{
+		Bitmap:     true,
+		Characters: map[string]Character{},
+		Kerning:    map[string]int{},
+	}
+
+	base := 0
+	for _, record := range records {
+		if record.tag == "common" {
+			base, _ = strconv.Atoi(record.attrs["base"])
+		}
+	}
+
+	var err error
+	integer := func(record bmRecord, key string) int {
+		value, ok := record.attrs[key]
+		if !ok || err != nil {
+			return 0
+		}
+		n, parseErr := strconv.Atoi(value)
+		if parseErr != nil {
+			err = fmt.Errorf("bmfont %s: invalid %s '%s'", record.tag, key, value)
+		}
+		return n
+	}
+
+	for _, record := range records {
+		switch record.tag {
+		case "info":
+			fontData.Name = record.attrs["face"]
+			fontData.Size = abs(integer(record, "size"))
+			fontData.Bold = integer(record, "bold") != 0
+			fontData.Italic = integer(record, "italic") != 0
+		case "common":
+			fontData.LineHeight = integer(record, "lineHeight")
+			fontData.Base = integer(record, "base")
+			fontData.Width = integer(record, "scaleW")
+			fontData.Height = integer(record, "scaleH")
+		case "page":
+			id := integer(record, "id")
+			if err == nil && (id < 0 || id > 255) {
+				err = fmt.Errorf("bmfont page: invalid id %d", id)
+			}
+			for err == nil && len(fontData.Pages) <= id {
+				fontData.Pages = append(fontData.Pages, "")
+			}
+			if err == nil {
+				fontData.Pages[id] = record.attrs["file"]
+			}
+		case "char":
+			fontData.Characters[string(rune(integer(record, "id")))] = Character{
+				X:       integer(record, "x"),
+				Y:       integer(record, "y"),
+				Width:   integer(record, "width"),
+				Height:  integer(record, "height"),
+				OriginX: -integer(record, "xoffset"),
+				OriginY: base - integer(record, "yoffset"),
+				Advance: integer(record, "xadvance"),
+				Page:    integer(record, "page"),
+			}
+		case "kerning":
+			pair := string(rune(integer(record, "first"))) + string(rune(integer(record, "second")))
+			fontData.Kerning[pair] = integer(record, "amount")
+		}
+		if err != nil {
+			return FontData{}, err
+		}
+	}
+
+	if fontData.Size == 0 {
+		fontData.Size = fontData.LineHeight
+	}
+	for i, page := range fontData.Pages {
+		if page == "" {
+			return FontData{}, fmt.Errorf("bmfont: missing page %d", i)
+		}
+	}
+	return fontData, nil
+}
+
+func parseBMFontBinary(data []byte) (FontData, error) {
+	if len(data) < 4 || data[3] != 3 {
+		return FontData{}, fmt.Errorf("bmfont: unsupported binary version")
+	}
+
+	fontData := FontData{
+		Bitmap:     true,
+		Characters: map[string]Character{},
+		Kerning:    map[string]int{},
+	}
+	base := 0
+
+	for offset := 4; offset < len(data); {
+		if offset+5 > len(data) {
+			return FontData{}, fmt.Errorf("bmfont: truncated block header")
+		}
+		blockType := data[offset]
+		size := int(binary.LittleEndian.Uint32(data[offset+1:]))
+		offset += 5
+		if size < 0 || offset+size > len(data) {
+			return FontData{}, fmt.Errorf("bmfont: truncated block %d", blockType)
+		}
+		block := data[offset : offset+size]
+		offset += size
+
+		switch blockType {
+		case 1:
+			if len(block) < 15 {
+				return FontData{}, fmt.Errorf("bmfont: invalid info block")
+			}
+			fontData.Size = abs(int(int16(binary.LittleEndian.Uint16(block))))
+			fontData.Italic = block[2]&0x20 != 0
+			fontData.Bold = block[2]&0x10 != 0
+			name := block[14:]
+			if end := bytes.IndexByte(name, 0); end >= 0 {
+				name = name[:end]
+			}
+			fontData.Name = string(name)
+		case 2:
+			if len(block) < 10 {
+				return FontData{}, fmt.Errorf("bmfont: invalid common block")
+			}
+			fontData.LineHeight = int(binary.LittleEndian.Uint16(block[0:]))
+			base = int(binary.LittleEndian.Uint16(block[2:]))
+			fontData.Base = base
+			fontData.Width = int(binary.LittleEndian.Uint16(block[4:]))
+			fontData.Height = int(binary.LittleEndian.Uint16(block[6:]))
+		case 3:
+			for _, name := range bytes.Split(bytes.TrimRight(block, "\x00"), []byte{0}) {
+				fontData.Pages = append(fontData.Pages, string(name))
+			}
+		case 4:
+			if len(block)%20 != 0 {
+				return FontData{}, fmt.Errorf("bmfont: invalid chars block")
+			}
+			for i := 0; i < len(block); i += 20 {
+				char := block[i : i+20]
+				id := rune(binary.LittleEndian.Uint32(char[0:]))
+				fontData.Characters[string(id)] = Character{
+					X:       int(binary.LittleEndian.Uint16(char[4:])),
+					Y:       int(binary.LittleEndian.Uint16(char[6:])),
+					Width:   int(binary.LittleEndian.Uint16(char[8:])),
+					Height:  int(binary.LittleEndian.Uint16(char[10:])),
+					OriginX: -int(int16(binary.LittleEndian.Uint16(char[12:]))),
+					OriginY: base - int(int16(binary.LittleEndian.Uint16(char[14:]))),
+					Advance: int(int16(binary.LittleEndian.Uint16(char[16:]))),
+					Page:    int(char[18]),
+				}
+			}
+		case 5:
+			if len(block)%10 != 0 {
+				return FontData{}, fmt.Errorf("bmfont: invalid kerning block")
+			}
+			for i := 0; i < len(block); i += 10 {
+				first := rune(binary.LittleEndian.Uint32(block[i:]))
+				second := rune(binary.LittleEndian.Uint32(block[i+4:]))
+				fontData.Kerning[string(first)+string(second)] = int(int16(binary.LittleEndian.Uint16(block[i+8:])))
+			}
+		default:
+			return FontData{}, fmt.Errorf("bmfont: unknown block type %d", blockType)
+		}
+	}
+
+	if fontData.Size == 0 {
+		fontData.Size = fontData.LineHeight
+	}
+	return fontData, nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,150 @@
+package graphics
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+const bmFontText = `info face="Test Sans" size=-16 bold=1 italic=0 charset="" unicode=1
+common lineHeight=19 base=15 scaleW=256 scaleH=128 pages=2 packed=0
+page id=0 file="test_0.png"
+page id=1 file="test_1.png"
+chars count=2
+char id=65 x=10 y=20 width=9 height=11 xoffset=-1 yoffset=4 xadvance=10 page=0 chnl=15
+char id=1074 x=30 y=40 width=8 height=8 xoffset=0 yoffset=7 xadvance=9 page=1 chnl=15
+kernings count=1
+kerning first=65 second=1074 amount=-2
+`
+
+const bmFontXML = `<?xml version="1.0"?>
+<font>
+  <info face="Test Sans" size="-16" bold="1" italic="0" unicode="1"/>
+  <common lineHeight="19" base="15" scaleW="256" scaleH="128" pages="2" packed="0"/>
+  <pages>
+    <page id="0" file="test_0.png"/>
+    <page id="1" file="test_1.png"/>
+  </pages>
+  <chars count="2">
+    <char id="65" x="10" y="20" width="9" height="11" xoffset="-1" yoffset="4" xadvance="10" page="0" chnl="15"/>
+    <char id="1074" x="30" y="40" width="8" height="8" xoffset="0" yoffset="7" xadvance="9" page="1" chnl="15"/>
+  </chars>
+  <kernings count="1">
+    <kerning first="65" second="1074" amount="-2"/>
+  </kernings>
+</font>
+`
+
+func bmFontBinary(bitField byte) []byte {
+	data := &bytes.Buffer{}
+	data.WriteString("BMF\x03")
+	block := func(blockType byte, contents []byte) {
+		data.WriteByte(blockType)
+		binary.Write(data, binary.LittleEndian, uint32(len(contents)))
+		data.Write(contents)
+	}
+	little := func(values ...interface{}) []byte {
+		out := &bytes.Buffer{}
+		for _, value := range values {
+			binary.Write(out, binary.LittleEndian, value)
+		}
+		return out.Bytes()
+	}
+
+	info := little(int16(-16), bitField, uint8(0), uint16(100), uint8(1), uint8(0), uint8(0), uint8(0), uint8(0), uint8(0), uint8(1), uint8(1))
+	block(1, append(info, "Test Sans\x00"...))
+	block(2, little(uint16(19), uint16(15), uint16(256), uint16(128), uint16(2), uint8(0), uint8(0), uint8(0), uint8(0), uint8(0)))
+	block(3, []byte("test_0.png\x00test_1.png\x00"))
+	block(4, little(
+		uint32(65), uint16(10), uint16(20), uint16(9), uint16(11), int16(-1), int16(4), int16(10), uint8(0), uint8(15),
+		uint32(1074), uint16(30), uint16(40), uint16(8), uint16(8), int16(0), int16(7), int16(9), uint8(1), uint8(15),
+	))
+	block(5, little(uint32(65), uint32(1074), int16(-2)))
+	return data.Bytes()
+}
+
+func checkBMFont(t *testing.T, format string, fontData FontData, bold, italic bool) {
+	if fontData.Name != "Test Sans" || fontData.Size != 16 || fontData.Bold != bold || fontData.Italic != italic || !fontData.Bitmap {
+		t.Errorf("%s: got info %q size %d bold %v italic %v bitmap %v", format, fontData.Name, fontData.Size, fontData.Bold, fontData.Italic, fontData.Bitmap)
+	}
+	if fontData.LineHeight != 19 || fontData.Base != 15 || fontData.Width != 256 || fontData.Height != 128 {
+		t.Errorf("%s: got common %d %d %d %d", format, fontData.LineHeight, fontData.Base, fontData.Width, fontData.Height)
+	}
+	if len(fontData.Pages) != 2 || fontData.Pages[0] != "test_0.png" || fontData.Pages[1] != "test_1.png" {
+		t.Errorf("%s: got pages %v", format, fontData.Pages)
+	}
+	want := map[string]Character{
+		"A": {X: 10, Y: 20, Width: 9, Height: 11, OriginX: 1, OriginY: 11, Advance: 10, Page: 0},
+		"в": {X: 30, Y: 40, Width: 8, Height: 8, OriginX: 0, OriginY: 8, Advance: 9, Page: 1},
+	}
+	for r, char := range want {
+		if got := fontData.Characters[r]; got != char {
+			t.Errorf("%s: character %s = %+v, want %+v", format, r, got, char)
+		}
+	}
+	if len(fontData.Characters) != len(want) {
+		t.Errorf("%s: got %d characters, want %d", format, len(fontData.Characters), len(want))
+	}
+	if fontData.Kerning["Aв"] != -2 || len(fontData.Kerning) != 1 {
+		t.Errorf("%s: got kerning %v", format, fontData.Kerning)
+	}
+}
+
+func TestParseBMFont(t *testing.T) {
+	formats := []struct {
+		name string
+		data []byte
+	}{
+		{"text", []byte(bmFontText)},
+		{"xml", []byte(bmFontXML)},
+		{"binary", bmFontBinary(0x10 | 0x40)},
+	}
+	for _, format := range formats {
+		fontData, err := ParseBMFont(format.data)
+		if err != nil {
+			t.Errorf("%s: %v", format.name, err)
+			continue
+		}
+		checkBMFont(t, format.name, fontData, true, false)
+	}
+}
+
+func TestParseBMFontBinaryStyleBits(t *testing.T) {
+	tests := []struct {
+		bitField     byte
+		bold, italic bool
+	}{
+		{0x80 | 0x40, false, false},
+		{0x20, false, true},
+		{0x10, true, false},
+		{0x30, true, true},
+		{0x04 | 0x08, false, false},
+	}
+	for _, test := range tests {
+		fontData, err := ParseBMFont(bmFontBinary(test.bitField))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fontData.Bold != test.bold || fontData.Italic != test.italic {
+			t.Errorf("bits %#x: got bold %v italic %v, want %v %v", test.bitField, fontData.Bold, fontData.Italic, test.bold, test.italic)
+		}
+	}
+}
+
+func TestParseBMFontErrors(t *testing.T) {
+	truncated := bmFontBinary(0)
+	inputs := map[string][]byte{
+		"binary version":   []byte("BMF\x02"),
+		"truncated binary": truncated[:len(truncated)-3],
+		"text attribute":   []byte("info face"),
+		"text string":      []byte(`info face="Test`),
+		"text number":      []byte("char id=x"),
+		"missing page":     []byte("page id=1 file=\"a.png\""),
+		"xml":              []byte("<font><info"),
+	}
+	for name, data := range inputs {
+		if _, err := ParseBMFont(data); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,411 @@
+package graphics
+
+import (
+	"math"
+	"sort"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+type BooleanOp int
+
+const (
+	BooleanUnion BooleanOp = iota
+	BooleanIntersection
+	BooleanDifference
+	BooleanXor
+)
+
+type point64 struct {
+	x, y float64
+}
+
+type booleanEdge struct {
+	a, b   point64
+	splits []float64
+}
+
+func (p point64) sub(o point64) point64 {
+	return point64{p.x - o.x, p.y - o.y}
+}
+
+func (p point64) cross(o point64) float64 {
+	return p.x*o.y - p.y*o.x
+}
+
+func (p point64) length() float64 {
+	return math.Hypot(p.x, p.y)
+}
+
+func (p point64) vec2() mgl32.Vec2 {
+	return mgl32.Vec2{float32(p.x), float32(p.y)}
+}
+
+func pathPolygons(path *Path) [][]point64 {
+	polygons := [][]point64{}
+	for _, polyline := range path.Flatten(path.flatTolerance()) {
+		polygon := []point64{}
+		for _, point := range polyline.Points {
+			p := point64{float64(point.X()), float64(point.Y())}
+			if len(polygon) == 0 || polygon[len(polygon)-1] != p {
+				polygon = append(polygon, p)
+			}
+		}
+		if len(polygon) > 1 && polygon[0] == polygon[len(polygon)-1] {
+			polygon = polygon[:len(polygon)-1]
+		}
+		if len(polygon) >= 3 {
+			polygons = append(polygons, polygon)
+		}
+	}
+	return polygons
+}
+
+func polygonWinding(polygons [][]point64, p point64) int {
+	winding := 0
+	for _, polygon := range polygons {
+		for i, a := range polygon {
+			b := polygon[(i+1)%len(polygon)]
+			if a.y <= p.y {
+				if b.y > p.y && b.sub(a).cross(p.sub(a)) > 0 {
+					winding++
+				}
+			} else if b.y <= p.y && b.sub(a).cross(p.sub(a)) < 0 {
+				winding--
+			}
+		}
+	}
+	return winding
+}
+
+func windingInside(winding int, rule FillRule) bool {
+	if rule == FillEvenOdd {
+		return winding%2 != 0
+	}
+	return winding != 0
+}
+
+func (path *Path) Boolean(other *Path, op BooleanOp, rule FillRule) Path {
+	a, b := pathPolygons(path), pathPolygons(other)
+	return resolvePolygons(append(append([][]point64{}, a...), b...), func(p point64) bool {
+		inA := windingInside(polygonWinding(a, p), rule)
+		inB := windingInside(polygonWinding(b, p), rule)
+		switch op {
+		case BooleanIntersection:
+			return inA && inB
+		case BooleanDifference:
+			return inA && !inB
+		case BooleanXor:
+			return inA != inB
+		}
+		return inA || inB
+	})
+}
+
+func (path *Path) Union(other *Path) Path {
+	return path.Boolean(other, BooleanUnion, FillNonZero)
+}
+
+func (path *Path) Intersection(other *Path) Path {
+	return path.Boolean(other, BooleanIntersection, FillNonZero)
+}
+
+func (path *Path) Difference(other *Path) Path {
+	return path.Boolean(other, BooleanDifference, FillNonZero)
+}
+
+func (path *Path) Xor(other *Path) Path {
+	return path.Boolean(other, BooleanXor, FillNonZero)
+}
+
+func resolvePolygons(polygons [][]point64, inside func(p point64) bool) Path {
+	edges := []booleanEdge{}
+	min, max := point64{math.Inf(1), math.Inf(1)}, point64{math.Inf(-1), math.Inf(-1)}
+	for _, polygon := range polygons {
+		for i, a := range polygon {
+			edges = append(edges, booleanEdge{a: a, b: polygon[(i+1)%len(polygon)]})
+			min = point64{math.Min(min.x, a.x), math.Min(min.y, a.y)}
+			max = point64{math.Max(max.x, a.x), math.Max(max.y, a.y)}
+		}
+	}
+	if len(edges) == 0 {
+		return Path{}
+	}
+	extent := math.Max(max.x-min.x, max.y-min.y)
+	if extent == 0 {
+		return Path{}
+	}
+	epsilon := math.Exp2(math.Floor(math.Log2(extent * 1e-6)))
+
+	// Every pair of edges is tested, so this is O(n²) in the edge count;
+	// simplify dense outlines before combining them.
+	for i := range edges {
+		for j := i + 1; j < len(edges); j++ {
+			splitEdges(&edges[i], &edges[j], epsilon)
+		}
+	}
+
+	snap := func(p point64) point64 {
+		return point64{math.Round(p.x/epsilon) * epsilon, math.Round(p.y/epsilon) * epsilon}
+	}
+	type edgeKey struct {
+		a, b point64
+	}
+	kept := map[edgeKey]bool{}
+	outgoing := map[point64][]point64{}
+	for _, edge := range edges {
+		sort.Float64s(edge.splits)
+		ts := append(append([]float64{0}, edge.splits...), 1)
+		direction := edge.b.sub(edge.a)
+		for i := 0; i+1 < len(ts); i++ {
+			a := snap(point64{edge.a.x + direction.x*ts[i], edge.a.y + direction.y*ts[i]})
+			b := snap(point64{edge.a.x + direction.x*ts[i+1], edge.a.y + direction.y*ts[i+1]})
+			segment := b.sub(a)
+			length := segment.length()
+			if length < epsilon {
+				continue
+			}
+			middle := point64{(a.x + b.x) / 2, (a.y + b.y) / 2}
+			normal := point64{-segment.y / length * epsilon * 4, segment.x / length * epsilon * 4}
+			left := inside(point64{middle.x + normal.x, middle.y + normal.y})
+			right := inside(point64{middle.x - normal.x, middle.y - normal.y})
+			if left == right {
+				continue
+			}
+			if right {
+				a, b = b, a
+			}
+			if kept[edgeKey{a, b}] {
+				continue
+			}
+			kept[edgeKey{a, b}] = true
+			outgoing[a] = append(outgoing[a], b)
+		}
+	}
+
+	starts := make([]point64, 0, len(outgoing))
+	for start := range outgoing {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool {
+		if starts[i].y != starts[j].y {
+			return starts[i].y < starts[j].y
+		}
+		return starts[i].x < starts[j].x
+	})
+
+	result := Path{}
+	for _, start := range starts {
+		for len(outgoing[start]) > 0 {
+			loop := []point64{start}
+			current := start
+			for {
+				targets := outgoing[current]
+				if len(targets) == 0 {
+					break
+				}
+				next := targets[len(targets)-1]
+				outgoing[current] = targets[:len(targets)-1]
+				if next == start {
+					break
+				}
+				loop = append(loop, next)
+				current = next
+			}
+			loop = removeCollinear(loop, epsilon)
+			if len(loop) < 3 {
+				continue
+			}
+			result.MoveTo(loop[0].vec2())
+			for _, p := range loop[1:] {
+				result.LineTo(p.vec2())
+			}
+			result.ClosePath()
+		}
+	}
+	return result
+}
+
+func splitEdges(e, f *booleanEdge, epsilon float64) {
+	r, s := e.b.sub(e.a), f.b.sub(f.a)
+	denominator := r.cross(s)
+	offset := f.a.sub(e.a)
+	if math.Abs(denominator) > epsilon*epsilon {
+		t := offset.cross(s) / denominator
+		u := offset.cross(r) / denominator
+		if t > 0 && t < 1 && u >= 0 && u <= 1 {
+			e.splits = append(e.splits, t)
+		}
+		if u > 0 && u < 1 && t >= 0 && t <= 1 {
+			f.splits = append(f.splits, u)
+		}
+		return
+	}
+	addOnSegment := func(edge *booleanEdge, p point64) {
+		direction := edge.b.sub(edge.a)
+		length := direction.length()
+		if length == 0 || math.Abs(direction.cross(p.sub(edge.a)))/length > epsilon {
+			return
+		}
+		t := (p.sub(edge.a).x*direction.x + p.sub(edge.a).y*direction.y) / (length * length)
+		if t > 0 && t < 1 {
+			edge.splits = append(edge.splits, t)
+		}
+	}
+	addOnSegment(e, f.a)
+	addOnSegment(e, f.b)
+	addOnSegment(f, e.a)
+	addOnSegment(f, e.b)
+}
+
+func removeCollinear(loop []point64, epsilon float64) []point64 {
+	for changed := true; changed && len(loop) >= 3; {
+		changed = false
+		for i := 0; i < len(loop) && len(loop) >= 3; i++ {
+			prev, next := loop[(i+len(loop)-1)%len(loop)], loop[(i+1)%len(loop)]
+			edge := next.sub(prev)
+			length := edge.length()
+			if length == 0 || math.Abs(edge.cross(loop[i].sub(prev)))/length <= epsilon {
+				loop = append(loop[:i], loop[i+1:]...)
+				changed = true
+				i--
+			}
+		}
+	}
+	return loop
+}
+
+func (path *Path) Offset(distance float32, join LineJoin, miterLimit float32) Path {
+	if miterLimit <= 0 {
+		miterLimit = DefaultMiterLimit
+	}
+	resolved := path.Boolean(&Path{}, BooleanUnion, FillNonZero)
+	if distance == 0 {
+		return resolved
+	}
+	d := float64(distance)
+	tolerance := float64(path.flatTolerance())
+	offsets := [][]point64{}
+	for _, polygon := range pathPolygons(&resolved) {
+		offset := []point64{}
+		n := len(polygon)
+		for i := range polygon {
+			prev, current, next := polygon[(i+n-1)%n], polygon[i], polygon[(i+1)%n]
+			in, out := current.sub(prev), next.sub(current)
+			in = point64{in.x / in.length(), in.y / in.length()}
+			out = point64{out.x / out.length(), out.y / out.length()}
+			n1 := point64{in.y * d, -in.x * d}
+			n2 := point64{out.y * d, -out.x * d}
+			p1 := point64{current.x + n1.x, current.y + n1.y}
+			p2 := point64{current.x + n2.x, current.y + n2.y}
+			turn := in.cross(out)
+			if math.Abs(turn) < 1e-9 && in.x*out.x+in.y*out.y > 0 {
+				offset = append(offset, p1)
+				continue
+			}
+			if turn*d < 0 {
+				offset = append(offset, p1, current, p2)
+				continue
+			}
+			switch join {
+			case JoinRound:
+				angle := math.Atan2(turn, in.x*out.x+in.y*out.y)
+				steps := arcSegments(pathArc{radius: mgl32.Vec2{float32(math.Abs(d)), float32(math.Abs(d))}, deltaAngle: angle}, float32(tolerance))
+				start := math.Atan2(n1.y, n1.x)
+				for step := 0; step <= steps; step++ {
+					theta := start + angle*float64(step)/float64(steps)
+					offset = append(offset, point64{current.x + math.Abs(d)*math.Cos(theta), current.y + math.Abs(d)*math.Sin(theta)})
+				}
+			case JoinMiter:
+				cosine := in.x*out.x + in.y*out.y
+				scale := 1 / math.Sqrt((1+cosine)/2)
+				if scale <= float64(miterLimit) {
+					bisector := point64{n1.x + n2.x, n1.y + n2.y}
+					length := bisector.length()
+					miter := math.Abs(d) * scale
+					offset = append(offset, point64{current.x + bisector.x/length*miter, current.y + bisector.y/length*miter})
+					continue
+				}
+				offset = append(offset, p1, p2)
+			default:
+				offset = append(offset, p1, p2)
+			}
+		}
+		offsets = append(offsets, offset)
+	}
+	return resolvePolygons(offsets, func(p point64) bool {
+		return polygonWinding(offsets, p) > 0
+	})
+}
+
+func (path *Path) Simplify(tolerance float32) Path {
+	result := Path{}
+	for _, polyline := range path.Flatten(path.flatTolerance()) {
+		points := polyline.Points
+		if polyline.Closed && len(points) > 1 && points[0].ApproxEqual(points[len(points)-1]) {
+			points = points[:len(points)-1]
+		}
+		if len(points) < 2 {
+			continue
+		}
+		var simplified []mgl32.Vec2
+		if polyline.Closed {
+			far := 0
+			for i, point := range points {
+				if point.Sub(points[0]).Len() > points[far].Sub(points[0]).Len() {
+					far = i
+				}
+			}
+			first := simplifyPolyline(points[:far+1], tolerance)
+			second := simplifyPolyline(append(append([]mgl32.Vec2{}, points[far:]...), points[0]), tolerance)
+			simplified = append(first, second[1:len(second)-1]...)
+		} else {
+			simplified = simplifyPolyline(points, tolerance)
+		}
+		result.MoveTo(simplified[0])
+		for _, point := range simplified[1:] {
+			result.LineTo(point)
+		}
+		if polyline.Closed {
+			result.ClosePath()
+		}
+	}
+	return result
+}
+
+func simplifyPolyline(points []mgl32.Vec2, tolerance float32) []mgl32.Vec2 {
+	if len(points) < 3 {
+		return append([]mgl32.Vec2{}, points...)
+	}
+	first, last := points[0], points[len(points)-1]
+	edge := last.Sub(first)
+	index, distance := 0, float32(0)
+	for i := 1; i < len(points)-1; i++ {
+		offset := points[i].Sub(first)
+		d := offset.Len()
+		if length := edge.Len(); length > 0 {
+			d = float32(math.Abs(float64(edge.X()*offset.Y()-edge.Y()*offset.X()))) / length
+		}
+		if d > distance {
+			index, distance = i, d
+		}
+	}
+	if distance <= tolerance {
+		return []mgl32.Vec2{first, last}
+	}
+	left := simplifyPolyline(points[:index+1], tolerance)
+	right := simplifyPolyline(points[index:], tolerance)
+	return append(left[:len(left)-1], right...)
+}
+
+func (path *Path) Polygons() [][]mgl32.Vec2 {
+	polygons := [][]mgl32.Vec2{}
+	for _, polygon := range pathPolygons(path) {
+		points := make([]mgl32.Vec2, len(polygon))
+		for i, p := range polygon {
+			points[i] = p.vec2()
+		}
+		polygons = append(polygons, points)
+	}
+	return polygons
+}
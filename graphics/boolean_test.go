@@ -0,0 +1,103 @@
+package graphics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestBooleanAreas(t *testing.T) {
+	square := Rect(mgl32.Vec2{0, 0}, mgl32.Vec2{10, 10})
+	overlapping := Rect(mgl32.Vec2{5, 5}, mgl32.Vec2{10, 10})
+	disjoint := Rect(mgl32.Vec2{20, 20}, mgl32.Vec2{10, 10})
+	tests := []struct {
+		name  string
+		other Path
+		op    BooleanOp
+		area  float64
+	}{
+		{"overlapping union", overlapping, BooleanUnion, 175},
+		{"overlapping intersection", overlapping, BooleanIntersection, 25},
+		{"overlapping difference", overlapping, BooleanDifference, 75},
+		{"overlapping xor", overlapping, BooleanXor, 150},
+		{"disjoint union", disjoint, BooleanUnion, 200},
+		{"disjoint intersection", disjoint, BooleanIntersection, 0},
+		{"disjoint difference", disjoint, BooleanDifference, 100},
+		{"disjoint xor", disjoint, BooleanXor, 200},
+		{"self difference", square, BooleanDifference, 0},
+		{"self union", square, BooleanUnion, 100},
+	}
+	for _, test := range tests {
+		result := square.Boolean(&test.other, test.op, FillNonZero)
+		if _, _, area := shapeExtent(result); math.Abs(area-test.area) > 1e-3 {
+			t.Errorf("%s: got area %v, want %v", test.name, area, test.area)
+		}
+		if test.area == 0 && len(result.Polygons()) != 0 {
+			t.Errorf("%s: got %d polygons, want an empty path", test.name, len(result.Polygons()))
+		}
+	}
+
+	if union := square.Union(&overlapping); len(union.Polygons()) != 1 || len(union.Polygons()[0]) != 8 {
+		t.Errorf("got union polygons %v, want a single octagon outline", union.Polygons())
+	}
+}
+
+func TestOffsetJoins(t *testing.T) {
+	square := Rect(mgl32.Vec2{0, 0}, mgl32.Vec2{10, 10})
+	tests := []struct {
+		name     string
+		distance float32
+		join     LineJoin
+		area     float64
+	}{
+		{"miter outset", 2, JoinMiter, 196},
+		{"bevel outset", 2, JoinBevel, 188},
+		{"round outset", 2, JoinRound, 180 + 4*math.Pi},
+		{"miter inset", -2, JoinMiter, 36},
+		{"bevel inset", -2, JoinBevel, 36},
+		{"round inset", -2, JoinRound, 36},
+		{"zero", 0, JoinMiter, 100},
+		{"collapsed inset", -6, JoinMiter, 0},
+	}
+	for _, test := range tests {
+		offset := square.Offset(test.distance, test.join, 0)
+		if _, _, area := shapeExtent(offset); math.Abs(area-test.area) > test.area*0.01+1e-3 {
+			t.Errorf("%s: got area %v, want %v", test.name, area, test.area)
+		}
+	}
+
+	limited := square.Offset(2, JoinMiter, 1)
+	if _, _, area := shapeExtent(limited); math.Abs(area-188) > 1e-3 {
+		t.Errorf("miter past the limit: got area %v, want the bevelled 188", area)
+	}
+}
+
+func TestSimplifyDenseCircle(t *testing.T) {
+	circle := Circle(mgl32.Vec2{0, 0}, 100)
+	circle.tolerance = 0.01
+	dense := circle.Flatten(0.01)[0].Points
+
+	simplified := circle.Simplify(1)
+	polylines := simplified.Flatten(0.01)
+	if len(polylines) != 1 || !polylines[0].Closed {
+		t.Fatalf("got %d polylines, want a single closed polyline", len(polylines))
+	}
+	points := polylines[0].Points
+	if len(points) < 8 || len(points) >= len(dense)/4 {
+		t.Errorf("simplified %d points to %d, want far fewer but still a polygon", len(dense), len(points))
+	}
+	loop := append(append([]mgl32.Vec2{}, points...), points[0])
+	for _, point := range dense {
+		if d := distanceToPolyline(point, loop); d > 1.001 {
+			t.Errorf("point %v is %v from the simplified outline, want at most 1", point, d)
+			break
+		}
+	}
+	for _, point := range points {
+		if r := point.Len(); math.Abs(float64(r)-100) > 0.01 {
+			t.Errorf("simplified point %v is off the circle", point)
+			break
+		}
+	}
+}
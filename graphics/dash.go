@@ -0,0 +1,117 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func DashPolylines(polylines []Polyline, dashes []float32, offset float32) []Polyline {
+	pattern := dashPattern(dashes)
+	if pattern == nil {
+		return polylines
+	}
+	total := float32(0)
+	for _, dash := range pattern {
+		total += dash
+	}
+
+	result := []Polyline{}
+	for _, polyline := range polylines {
+		points := polyline.Points
+		if polyline.Closed && len(points) > 1 && !points[0].ApproxEqual(points[len(points)-1]) {
+			points = append(append([]mgl32.Vec2{}, points...), points[0])
+		}
+		if len(points) < 2 {
+			continue
+		}
+
+		phase := float32(math.Mod(float64(offset), float64(total)))
+		if phase < 0 {
+			phase += total
+		}
+		index := 0
+		for phase > pattern[index] || (phase == pattern[index] && pattern[index] > 0) {
+			phase -= pattern[index]
+			index = (index + 1) % len(pattern)
+		}
+		remaining := pattern[index] - phase
+		startsOn := index%2 == 0
+
+		dashed := []Polyline{}
+		current := Polyline{}
+		if startsOn {
+			current.Points = []mgl32.Vec2{points[0]}
+		}
+		for i := 0; i+1 < len(points); i++ {
+			a, b := points[i], points[i+1]
+			length := b.Sub(a).Len()
+			position := float32(0)
+			for length-position > remaining {
+				position += remaining
+				point := a.Add(b.Sub(a).Mul(position / length))
+				if index%2 == 0 {
+					current.Points = append(current.Points, point)
+					dashed = append(dashed, current)
+					current = Polyline{}
+				} else {
+					current.Points = []mgl32.Vec2{point}
+				}
+				index = (index + 1) % len(pattern)
+				remaining = pattern[index]
+			}
+			remaining -= length - position
+			if index%2 == 0 {
+				current.Points = append(current.Points, b)
+			}
+		}
+		endsOn := index%2 == 0
+		if endsOn {
+			dashed = append(dashed, current)
+		}
+
+		if polyline.Closed && startsOn && endsOn && len(dashed) > 1 {
+			last := dashed[len(dashed)-1]
+			dashed[0].Points = append(last.Points, dashed[0].Points[1:]...)
+			dashed = dashed[:len(dashed)-1]
+		}
+		for _, dash := range dashed {
+			if len(dash.Points) > 1 {
+				result = append(result, dash)
+			}
+		}
+	}
+	return result
+}
+
+func dashPattern(dashes []float32) []float32 {
+	if len(dashes) == 0 {
+		return nil
+	}
+	total := float32(0)
+	for _, dash := range dashes {
+		if dash < 0 {
+			return nil
+		}
+		total += dash
+	}
+	if total <= 0 {
+		return nil
+	}
+	if len(dashes)%2 == 1 {
+		return append(append([]float32{}, dashes...), dashes...)
+	}
+	return dashes
+}
+
+func equalDashes(a, b []float32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package graphics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestDashPolylines(t *testing.T) {
+	line := []Polyline{{Points: []mgl32.Vec2{{0, 0}, {20, 0}}}}
+	tests := []struct {
+		dashes []float32
+		offset float32
+		count  int
+	}{
+		{[]float32{5, 5}, 0, 2},
+		{[]float32{5, 5}, 5, 2},
+		{[]float32{5, 5}, 2.5, 3},
+		{[]float32{5}, 0, 2},
+		{[]float32{0, 5}, 0, 4},
+	}
+	for _, test := range tests {
+		if dashed := DashPolylines(line, test.dashes, test.offset); len(dashed) != test.count {
+			t.Errorf("%v offset %v: got %d dashes, want %d", test.dashes, test.offset, len(dashed), test.count)
+		}
+	}
+}
+
+func TestStrokeZeroLengthDashes(t *testing.T) {
+	line := []Polyline{{Points: []mgl32.Vec2{{0, 0}, {20, 0}}}}
+	tests := []struct {
+		cap       LineCap
+		triangles int
+	}{
+		{CapButt, 0},
+		{CapSquare, 4 * 8},
+		{CapRound, 4 * 2 * 8},
+	}
+	for _, test := range tests {
+		style := StrokeStyle{Width: 20, Cap: test.cap, Dashes: []float32{0, 5}}
+		if triangles := len(StrokePolylines(line, style, 0.25)) / 3; triangles != test.triangles {
+			t.Errorf("cap %v: got %d triangles, want %d", test.cap, triangles, test.triangles)
+		}
+	}
+}
+
+func TestStrokeDistanceAttributes(t *testing.T) {
+	line := []Polyline{{Points: []mgl32.Vec2{{0, 0}, {10, 0}, {10, 10}}}}
+	vertices := strokePolylines(line, StrokeStyle{Width: 2, Cap: CapSquare}, 0.25)
+	for _, vertex := range vertices {
+		var along, across float32
+		switch {
+		case vertex.pos.X() <= 10 && vertex.pos.Y() <= 1 && vertex.pos.Y() >= -1 && vertex.pos.X() < 9:
+			along, across = vertex.pos.X(), -vertex.pos.Y()
+		case vertex.pos.Y() > 1:
+			along, across = 10+vertex.pos.Y(), vertex.pos.X()-10
+		default:
+			continue
+		}
+		if math.Abs(float64(vertex.along-along)) > 1e-4 || math.Abs(float64(vertex.across-across)) > 1e-4 {
+			t.Errorf("vertex %v: along %v across %v, want %v %v", vertex.pos, vertex.along, vertex.across, along, across)
+		}
+	}
+}
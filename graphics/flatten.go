@@ -0,0 +1,236 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const maxCurveSegments = 256
+
+type Polyline struct {
+	Points []mgl32.Vec2
+	Closed bool
+}
+
+type pathBuilder struct {
+	points     []PathVertex
+	polylines  []Polyline
+	current    Polyline
+	lastPoint  mgl32.Vec2
+	lastNormal mgl32.Vec2
+	startPoint mgl32.Vec2
+	startIndex int
+}
+
+func perp(vec mgl32.Vec2) mgl32.Vec2 {
+	return mgl32.Vec2{vec.Y(), -vec.X()}
+}
+
+func (path *Path) Flatten(tolerance float32) []Polyline {
+	return path.flatten(tolerance).polylines
+}
+
+func (path *Path) flatten(tolerance float32) *pathBuilder {
+	builder := &pathBuilder{}
+	for _, segment := range path.segments {
+		p := segment.points
+		switch segment.kind {
+		case segmentMove:
+			builder.moveTo(p[0])
+		case segmentLine:
+			builder.lineTo(p[1])
+		case segmentQuadratic:
+			builder.curveTo(p[2], quadraticSegments(p[0], p[1], p[2], tolerance), func(t float32) mgl32.Vec2 {
+				return pointInQuadratic(p[0], p[1], p[2], t)
+			}, func(t float32) mgl32.Vec2 {
+				return normalInQuadratic(p[0], p[1], p[2], t)
+			})
+		case segmentCubic:
+			builder.curveTo(p[3], cubicSegments(p[0], p[1], p[2], p[3], tolerance), func(t float32) mgl32.Vec2 {
+				return pointInCubic(p[0], p[1], p[2], p[3], t)
+			}, func(t float32) mgl32.Vec2 {
+				return normalInCubic(p[0], p[1], p[2], p[3], t)
+			})
+		case segmentArc:
+			arc := segment.arc
+			builder.curveTo(p[1], arcSegments(arc, tolerance), func(t float32) mgl32.Vec2 {
+				return pointInArc(arc.center, arc.radius, arc.rotation, arc.startAngle+arc.deltaAngle*float64(t))
+			}, func(t float32) mgl32.Vec2 {
+				return normalInArc(arc.radius, arc.rotation, arc.startAngle+arc.deltaAngle*float64(t), arc.deltaAngle)
+			})
+		case segmentClose:
+			builder.closePath()
+		}
+	}
+	builder.finish()
+	return builder
+}
+
+func clampSegments(n float64) int {
+	if math.IsNaN(n) || n < 1 {
+		return 1
+	}
+	if n > maxCurveSegments {
+		return maxCurveSegments
+	}
+	return int(math.Ceil(n))
+}
+
+func quadraticSegments(start, control, end mgl32.Vec2, tolerance float32) int {
+	deviation := start.Sub(control.Mul(2)).Add(end).Len()
+	return clampSegments(math.Sqrt(float64(deviation / (4 * tolerance))))
+}
+
+func cubicSegments(start, control1, control2, end mgl32.Vec2, tolerance float32) int {
+	deviation := max32(start.Sub(control1.Mul(2)).Add(control2).Len(), control1.Sub(control2.Mul(2)).Add(end).Len())
+	return clampSegments(math.Sqrt(float64(0.75 * deviation / tolerance)))
+}
+
+func arcSegments(arc pathArc, tolerance float32) int {
+	radius := float64(max32(arc.radius.X(), arc.radius.Y()))
+	ratio := math.Max(1-float64(tolerance)/radius, -1)
+	step := 2 * math.Acos(ratio)
+	if step <= 0 {
+		return maxCurveSegments
+	}
+	return clampSegments(math.Abs(arc.deltaAngle) / step)
+}
+
+func (path *pathBuilder) beginSegment(normal mgl32.Vec2) {
+	if !path.lastNormal.ApproxEqual(mgl32.Vec2{0, 0}) {
+		averageNormal := averageNormals(path.lastNormal, normal)
+		path.points = append(path.points, PathVertex{path.lastPoint, averageNormal}, PathVertex{path.lastPoint, averageNormal})
+	} else {
+		path.points = append(path.points, PathVertex{path.lastPoint, normal})
+	}
+}
+
+func (path *pathBuilder) lineTo(newPoint mgl32.Vec2) {
+	if newPoint.ApproxEqual(path.lastPoint) {
+		return
+	}
+	newNormal := perp(newPoint.Sub(path.lastPoint)).Normalize()
+	path.beginSegment(newNormal)
+	path.addPoint(newPoint)
+	path.lastPoint = newPoint
+	path.lastNormal = newNormal
+}
+
+func (path *pathBuilder) addPoint(point mgl32.Vec2) {
+	if len(path.current.Points) == 0 {
+		path.current.Points = append(path.current.Points, path.lastPoint)
+	}
+	path.current.Points = append(path.current.Points, point)
+}
+
+func (path *pathBuilder) endPolyline(closed bool) {
+	if len(path.current.Points) > 1 {
+		path.current.Closed = closed
+		path.polylines = append(path.polylines, path.current)
+	}
+	path.current = Polyline{}
+}
+
+func (path *pathBuilder) curveTo(newPoint mgl32.Vec2, n int, point, normal func(t float32) mgl32.Vec2) {
+	path.beginSegment(normal(0))
+	for i := 1; i < n; i++ {
+		t := float32(i) / float32(n)
+		position, direction := point(t), normal(t)
+		path.points = append(path.points, PathVertex{position, direction}, PathVertex{position, direction})
+		path.addPoint(position)
+	}
+	path.addPoint(newPoint)
+	path.lastPoint = newPoint
+	path.lastNormal = normal(1)
+}
+
+func (path *pathBuilder) closePath() {
+	if path.lastNormal.ApproxEqual(mgl32.Vec2{0, 0}) {
+		return
+	}
+	path.lineTo(path.startPoint)
+
+	first := path.points[path.startIndex]
+	averageNormal := averageNormals(path.lastNormal, first.normal)
+	path.points[path.startIndex].normal = averageNormal
+	path.points = append(path.points, PathVertex{path.startPoint, averageNormal})
+	path.endPolyline(true)
+
+	path.lastPoint = path.startPoint
+	path.lastNormal = mgl32.Vec2{0, 0}
+	path.startIndex = len(path.points)
+}
+
+func (path *pathBuilder) moveTo(newPoint mgl32.Vec2) {
+	if !path.lastNormal.ApproxEqual(mgl32.Vec2{0, 0}) {
+		path.points = append(path.points, PathVertex{path.lastPoint, path.lastNormal})
+	}
+	path.endPolyline(false)
+	path.lastPoint = newPoint
+	path.lastNormal = mgl32.Vec2{0, 0}
+	path.startPoint = newPoint
+	path.startIndex = len(path.points)
+}
+
+func (path *pathBuilder) finish() {
+	path.moveTo(path.lastPoint)
+}
+
+func normalInQuadratic(start, control, end mgl32.Vec2, t float32) mgl32.Vec2 {
+	tangent := control.Sub(start).Mul(2 * (1 - t)).Add(end.Sub(control).Mul(2 * t))
+	if tangent.ApproxEqual(mgl32.Vec2{0, 0}) {
+		tangent = end.Sub(start)
+	}
+	return perp(tangent).Normalize()
+}
+
+func pointInQuadratic(start, control, end mgl32.Vec2, t float32) mgl32.Vec2 {
+	return start.Mul((1 - t) * (1 - t)).Add(control.Mul(2 * (1 - t) * t)).Add(end.Mul(t * t))
+}
+
+func normalInCubic(start, control1, control2, end mgl32.Vec2, t float32) mgl32.Vec2 {
+	u := 1 - t
+	tangent := control1.Sub(start).Mul(3 * u * u).Add(control2.Sub(control1).Mul(6 * u * t)).Add(end.Sub(control2).Mul(3 * t * t))
+	if tangent.ApproxEqual(mgl32.Vec2{0, 0}) {
+		if t < 0.5 {
+			tangent = control2.Sub(start)
+		} else {
+			tangent = end.Sub(control1)
+		}
+		if tangent.ApproxEqual(mgl32.Vec2{0, 0}) {
+			tangent = end.Sub(start)
+		}
+	}
+	return perp(tangent).Normalize()
+}
+
+func pointInCubic(start, control1, control2, end mgl32.Vec2, t float32) mgl32.Vec2 {
+	u := 1 - t
+	return start.Mul(u * u * u).Add(control1.Mul(3 * u * u * t)).Add(control2.Mul(3 * u * t * t)).Add(end.Mul(t * t * t))
+}
+
+func averageNormals(n1, n2 mgl32.Vec2) mgl32.Vec2 {
+	normal := n1.Add(n2).Normalize()
+	factor := 1.0 / normal.Dot(n1)
+	return normal.Mul(factor)
+}
+
+func normalInArc(radius mgl32.Vec2, rotation, angle, sweep float64) mgl32.Vec2 {
+	sin, cos := math.Sincos(angle)
+	sinRotation, cosRotation := math.Sincos(rotation)
+	x := -float64(radius.X())*sin*cosRotation - float64(radius.Y())*cos*sinRotation
+	y := -float64(radius.X())*sin*sinRotation + float64(radius.Y())*cos*cosRotation
+	if sweep < 0 {
+		x, y = -x, -y
+	}
+	return perp(mgl32.Vec2{float32(x), float32(y)}).Normalize()
+}
+
+func pointInArc(center, radius mgl32.Vec2, rotation, angle float64) mgl32.Vec2 {
+	sin, cos := math.Sincos(angle)
+	sinRotation, cosRotation := math.Sincos(rotation)
+	x := float64(radius.X())*cos*cosRotation - float64(radius.Y())*sin*sinRotation
+	y := float64(radius.X())*cos*sinRotation + float64(radius.Y())*sin*cosRotation
+	return center.Add(mgl32.Vec2{float32(x), float32(y)})
+}
@@ -0,0 +1,134 @@
+package graphics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func distanceToPolyline(point mgl32.Vec2, points []mgl32.Vec2) float32 {
+	best := float32(math.Inf(1))
+	for i := 0; i+1 < len(points); i++ {
+		a, b := points[i], points[i+1]
+		edge := b.Sub(a)
+		t := float32(0)
+		if length := edge.Dot(edge); length > 0 {
+			t = min32(max32(point.Sub(a).Dot(edge)/length, 0), 1)
+		}
+		best = min32(best, a.Add(edge.Mul(t)).Sub(point).Len())
+	}
+	return best
+}
+
+func maxDeviation(points []mgl32.Vec2, curve func(t float32) mgl32.Vec2) float32 {
+	deviation := float32(0)
+	for i := 0; i <= 1000; i++ {
+		deviation = max32(deviation, distanceToPolyline(curve(float32(i)/1000), points))
+	}
+	return deviation
+}
+
+func TestFlattenCurves(t *testing.T) {
+	quadratic := Path{}
+	quadratic.MoveTo(mgl32.Vec2{0, 0})
+	quadratic.QuadraticTo(mgl32.Vec2{50, 100}, mgl32.Vec2{100, 0})
+
+	cubic := Path{}
+	cubic.MoveTo(mgl32.Vec2{0, 0})
+	cubic.CubicTo(mgl32.Vec2{0, 100}, mgl32.Vec2{100, 100}, mgl32.Vec2{100, 0})
+
+	arc := Path{}
+	arc.MoveTo(mgl32.Vec2{-100, 0})
+	arc.ArcTo(mgl32.Vec2{100, 100}, 0, false, true, mgl32.Vec2{100, 0})
+
+	curves := map[string]struct {
+		path  Path
+		curve func(t float32) mgl32.Vec2
+	}{
+		"quadratic": {quadratic, func(t float32) mgl32.Vec2 {
+			return pointInQuadratic(mgl32.Vec2{0, 0}, mgl32.Vec2{50, 100}, mgl32.Vec2{100, 0}, t)
+		}},
+		"cubic": {cubic, func(t float32) mgl32.Vec2 {
+			return pointInCubic(mgl32.Vec2{0, 0}, mgl32.Vec2{0, 100}, mgl32.Vec2{100, 100}, mgl32.Vec2{100, 0}, t)
+		}},
+		"arc": {arc, func(t float32) mgl32.Vec2 {
+			angle := math.Pi * float64(1+t)
+			return mgl32.Vec2{float32(100 * math.Cos(angle)), float32(100 * math.Sin(angle))}
+		}},
+	}
+
+	tests := []struct {
+		curve     string
+		tolerance float32
+		segments  int
+	}{
+		{"quadratic", 1, 8},
+		{"quadratic", 0.25, 15},
+		{"quadratic", 0.05, 32},
+		{"cubic", 1, 11},
+		{"cubic", 0.25, 21},
+		{"cubic", 0.05, 47},
+		{"arc", 1, 12},
+		{"arc", 0.25, 23},
+		{"arc", 0.05, 50},
+	}
+	for _, test := range tests {
+		curve := curves[test.curve]
+		polylines := curve.path.Flatten(test.tolerance)
+		if len(polylines) != 1 {
+			t.Fatalf("%s: got %d polylines, want 1", test.curve, len(polylines))
+		}
+		points := polylines[0].Points
+		if segments := len(points) - 1; segments != test.segments {
+			t.Errorf("%s at %v: got %d segments, want %d", test.curve, test.tolerance, segments, test.segments)
+		}
+		if deviation := maxDeviation(points, curve.curve); deviation > test.tolerance*1.01 {
+			t.Errorf("%s at %v: deviation %v exceeds tolerance", test.curve, test.tolerance, deviation)
+		}
+	}
+}
+
+func TestFlattenClampsSegments(t *testing.T) {
+	path := Path{}
+	path.MoveTo(mgl32.Vec2{0, 0})
+	path.QuadraticTo(mgl32.Vec2{5000, 10000}, mgl32.Vec2{10000, 0})
+	if segments := len(path.Flatten(0.001)[0].Points) - 1; segments != maxCurveSegments {
+		t.Errorf("got %d segments, want %d", segments, maxCurveSegments)
+	}
+
+	flat := Path{}
+	flat.MoveTo(mgl32.Vec2{0, 0})
+	flat.QuadraticTo(mgl32.Vec2{5, 0}, mgl32.Vec2{10, 0})
+	if segments := len(flat.Flatten(0.25)[0].Points) - 1; segments != 1 {
+		t.Errorf("straight quadratic: got %d segments, want 1", segments)
+	}
+}
+
+func TestFlattenWithTransformScalesTolerance(t *testing.T) {
+	path := Path{}
+	path.MoveTo(mgl32.Vec2{0, 0})
+	path.CubicTo(mgl32.Vec2{0, 100}, mgl32.Vec2{100, 100}, mgl32.Vec2{100, 0})
+
+	for _, scale := range []float32{0.5, 1, 4} {
+		transform := mgl32.Scale2D(scale, scale)
+		builder, tolerance := path.flattenWithTransform(transform, 1)
+		if math.Abs(float64(tolerance-1/scale)) > 1e-6 {
+			t.Errorf("scale %v: tolerance %v, want %v", scale, tolerance, 1/scale)
+		}
+		points := builder.polylines[0].Points
+		if want := len(path.Flatten(1 / scale)[0].Points); len(points) != want {
+			t.Errorf("scale %v: got %d points, want %d", scale, len(points), want)
+		}
+		screen := make([]mgl32.Vec2, len(points))
+		for i, point := range points {
+			screen[i] = point.Mul(scale)
+		}
+		deviation := maxDeviation(screen, func(t float32) mgl32.Vec2 {
+			return pointInCubic(mgl32.Vec2{0, 0}, mgl32.Vec2{0, 100}, mgl32.Vec2{100, 100}, mgl32.Vec2{100, 0}, t).Mul(scale)
+		})
+		if deviation > 1.01 {
+			t.Errorf("scale %v: screen deviation %v exceeds one pixel", scale, deviation)
+		}
+	}
+}
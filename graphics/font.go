@@ -4,32 +4,32 @@ import (
 	"github.com/go-gl/mathgl/mgl32"
 	"github.com/golang/freetype/truetype"
 	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
 )
 
 func GenerateCharacterPath(f *truetype.Font, r rune) Path {
-	i := f.Index(r)
+	return generateGlyphPath(f, f.Index(r), 50, 100.0)
+}
 
+func generateGlyphPath(f *truetype.Font, i truetype.Index, scale fixed.Int26_6, divisor float32) Path {
 	buffer := truetype.GlyphBuf{}
 
-	buffer.Load(f, 50, i, font.HintingNone)
+	buffer.Load(f, scale, i, font.HintingNone)
 
 	path := Path{}
+	path.SetTolerance(float32(scale) / divisor / 1000)
 
 	getPoint := func(i int) mgl32.Vec2 {
-		return mgl32.Vec2{float32(buffer.Points[i].X) / 100.0, float32(buffer.Points[i].Y) / 100.0}
+		return mgl32.Vec2{float32(buffer.Points[i].X) / divisor, float32(buffer.Points[i].Y) / divisor}
 	}
 
-	path.MoveTo(mgl32.Vec2{0, 0})
-
 	start := 0
 	for _, end := range buffer.Ends {
 		path.MoveTo(getPoint(start))
-		finished := false
 		for i := start + 1; i < end; i++ {
 			if buffer.Points[i].Flags&1 == 0 {
-				if i+1 >= len(buffer.Points) {
+				if i+1 >= end {
 					path.QuadraticTo(getPoint(i), getPoint(start))
-					finished = true
 				} else if buffer.Points[i+1].Flags&1 == 0 {
 					path.QuadraticTo(getPoint(i), getPoint(i).Add(getPoint(i+1)).Mul(0.5))
 				} else {
@@ -40,9 +40,7 @@ func GenerateCharacterPath(f *truetype.Font, r rune) Path {
 				path.LineTo(getPoint(i))
 			}
 		}
-		if !finished {
-			path.LineTo(getPoint(start))
-		}
+		path.ClosePath()
 		start = end
 	}
 
@@ -0,0 +1,235 @@
+package graphics
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+const sdfSupersample = 4
+
+type bakedGlyph struct {
+	r                rune
+	pixels           []uint8
+	width, height    int
+	originX, originY int
+	advance          int
+}
+
+func GenerateFont(ttf *truetype.Font, size int, runes []rune, padding int, atlasWidth int) (FontData, *image.NRGBA) {
+	if padding < 1 {
+		padding = 1
+	}
+
+	face := truetype.NewFace(ttf, &truetype.Options{Size: float64(size), Hinting: font.HintingNone})
+	hiFace := truetype.NewFace(ttf, &truetype.Options{Size: float64(size * sdfSupersample), Hinting: font.HintingNone})
+
+	glyphs := []bakedGlyph{}
+	for _, r := range runes {
+		if ttf.Index(r) == 0 {
+			continue
+		}
+		advance, ok := face.GlyphAdvance(r)
+		if !ok {
+			continue
+		}
+		glyph := bakeGlyph(hiFace, r, padding)
+		glyph.advance = advance.Round()
+		glyphs = append(glyphs, glyph)
+	}
+
+	order := make([]int, len(glyphs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return glyphs[order[i]].height > glyphs[order[j]].height
+	})
+
+	positions := make([]image.Point, len(glyphs))
+	x, y, rowHeight := 0, 0, 0
+	for _, i := range order {
+		glyph := glyphs[i]
+		if x+glyph.width > atlasWidth && x > 0 {
+			x = 0
+			y += rowHeight
+			rowHeight = 0
+		}
+		positions[i] = image.Point{x, y}
+		x += glyph.width
+		if glyph.height > rowHeight {
+			rowHeight = glyph.height
+		}
+	}
+
+	atlas := image.NewNRGBA(image.Rect(0, 0, atlasWidth, y+rowHeight))
+	for i := 3; i < len(atlas.Pix); i += 4 {
+		atlas.Pix[i] = 255
+	}
+	data := FontData{
+		Name:       ttf.Name(truetype.NameIDFontFamily),
+		Size:       size,
+		Width:      atlas.Rect.Dx(),
+		Height:     atlas.Rect.Dy(),
+		Characters: make(map[string]Character, len(glyphs)),
+	}
+	subfamily := ttf.Name(truetype.NameIDFontSubfamily)
+	data.Bold = strings.Contains(subfamily, "Bold")
+	data.Italic = strings.Contains(subfamily, "Italic") || strings.Contains(subfamily, "Oblique")
+
+	for i, glyph := range glyphs {
+		pos := positions[i]
+		for gy := 0; gy < glyph.height; gy++ {
+			for gx := 0; gx < glyph.width; gx++ {
+				v := glyph.pixels[gy*glyph.width+gx]
+				atlas.SetNRGBA(pos.X+gx, pos.Y+gy, color.NRGBA{v, v, v, 255})
+			}
+		}
+		data.Characters[string(glyph.r)] = Character{
+			X:       pos.X,
+			Y:       pos.Y,
+			Width:   glyph.width,
+			Height:  glyph.height,
+			OriginX: glyph.originX,
+			OriginY: glyph.originY,
+			Advance: glyph.advance,
+		}
+	}
+
+	return data, atlas
+}
+
+func FontFromAtlas(atlas *image.NRGBA, data FontData) Font {
+	return Font{textures: []Texture{TextureFromRGBA(atlas)}, data: data}
+}
+
+func bakeGlyph(hiFace font.Face, r rune, padding int) bakedGlyph {
+	dr, mask, maskp, _, _ := hiFace.Glyph(fixed.P(0, 0), r)
+
+	minX := floorDiv(dr.Min.X, sdfSupersample) - padding
+	minY := floorDiv(dr.Min.Y, sdfSupersample) - padding
+	maxX := ceilDiv(dr.Max.X, sdfSupersample) + padding
+	maxY := ceilDiv(dr.Max.Y, sdfSupersample) + padding
+	if dr.Empty() {
+		minX, minY, maxX, maxY = -padding, -padding, padding, padding
+	}
+
+	glyph := bakedGlyph{
+		r:       r,
+		width:   maxX - minX,
+		height:  maxY - minY,
+		originX: -minX,
+		originY: -minY,
+	}
+
+	hiWidth := glyph.width * sdfSupersample
+	hiHeight := glyph.height * sdfSupersample
+	inside := make([]bool, hiWidth*hiHeight)
+	outside := make([]bool, hiWidth*hiHeight)
+	for y := 0; y < hiHeight; y++ {
+		for x := 0; x < hiWidth; x++ {
+			p := image.Point{x + minX*sdfSupersample, y + minY*sdfSupersample}
+			in := false
+			if p.In(dr) {
+				_, _, _, a := mask.At(maskp.X+p.X-dr.Min.X, maskp.Y+p.Y-dr.Min.Y).RGBA()
+				in = a >= 0x8000
+			}
+			inside[y*hiWidth+x] = in
+			outside[y*hiWidth+x] = !in
+		}
+	}
+
+	toInside := distanceTransform(inside, hiWidth, hiHeight)
+	toOutside := distanceTransform(outside, hiWidth, hiHeight)
+
+	spread := float64(padding * sdfSupersample)
+	glyph.pixels = make([]uint8, glyph.width*glyph.height)
+	for y := 0; y < glyph.height; y++ {
+		for x := 0; x < glyph.width; x++ {
+			i := (y*sdfSupersample+sdfSupersample/2)*hiWidth + x*sdfSupersample + sdfSupersample/2
+			distance := math.Sqrt(toOutside[i]) - math.Sqrt(toInside[i])
+			value := 0.5 + distance/(2*spread)
+			glyph.pixels[y*glyph.width+x] = uint8(math.Round(math.Max(0, math.Min(1, value)) * 255))
+		}
+	}
+
+	return glyph
+}
+
+func distanceTransform(grid []bool, width, height int) []float64 {
+	inf := 1e20
+	dist := make([]float64, len(grid))
+	for i, set := range grid {
+		if !set {
+			dist[i] = inf
+		}
+	}
+
+	n := width
+	if height > n {
+		n = height
+	}
+	f := make([]float64, n)
+	d := make([]float64, n)
+	v := make([]int, n)
+	z := make([]float64, n+1)
+
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			f[y] = dist[y*width+x]
+		}
+		distanceTransform1D(f[:height], d, v, z)
+		for y := 0; y < height; y++ {
+			dist[y*width+x] = d[y]
+		}
+	}
+	for y := 0; y < height; y++ {
+		copy(f, dist[y*width:(y+1)*width])
+		distanceTransform1D(f[:width], d, v, z)
+		copy(dist[y*width:(y+1)*width], d[:width])
+	}
+
+	return dist
+}
+
+func distanceTransform1D(f, d []float64, v []int, z []float64) {
+	k := 0
+	v[0] = 0
+	z[0] = math.Inf(-1)
+	z[1] = math.Inf(1)
+	for q := 1; q < len(f); q++ {
+		s := ((f[q] + float64(q*q)) - (f[v[k]] + float64(v[k]*v[k]))) / float64(2*q-2*v[k])
+		for s <= z[k] {
+			k--
+			s = ((f[q] + float64(q*q)) - (f[v[k]] + float64(v[k]*v[k]))) / float64(2*q-2*v[k])
+		}
+		k++
+		v[k] = q
+		z[k] = s
+		z[k+1] = math.Inf(1)
+	}
+	k = 0
+	for q := 0; q < len(f); q++ {
+		for z[k+1] < float64(q) {
+			k++
+		}
+		d[q] = float64((q-v[k])*(q-v[k])) + f[v[k]]
+	}
+}
+
+func floorDiv(a, b int) int {
+	if a < 0 {
+		return -((-a + b - 1) / b)
+	}
+	return a / b
+}
+
+func ceilDiv(a, b int) int {
+	return -floorDiv(-a, b)
+}
@@ -0,0 +1,157 @@
+package graphics
+
+import (
+	"unsafe"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+
+	_ "embed"
+)
+
+//go:embed shaders/fringe.vs
+var FringeVS string
+
+//go:embed shaders/fringe.fs
+var FringeFS string
+
+const DefaultFringeWidth = 1
+
+type FillStyle struct {
+	Rule        FillRule
+	Antialias   bool
+	FringeWidth float32
+	Tessellate  bool
+}
+
+type fringeVertex struct {
+	pos      mgl32.Vec2
+	previous mgl32.Vec2
+	next     mgl32.Vec2
+	coverage float32
+}
+
+type fringeMesh struct {
+	vbo      Buffer
+	vao      uint32
+	size     int
+	created  bool
+	revision int
+}
+
+func fringeGeometry(polylines []Polyline) []fringeVertex {
+	vertices := []fringeVertex{}
+	for _, polyline := range polylines {
+		points := []mgl32.Vec2{}
+		for _, point := range polyline.Points {
+			if len(points) == 0 || !point.ApproxEqual(points[len(points)-1]) {
+				points = append(points, point)
+			}
+		}
+		closed := polyline.Closed && len(points) > 2
+		if closed && points[0].ApproxEqual(points[len(points)-1]) {
+			points = points[:len(points)-1]
+		}
+		if len(points) < 2 {
+			continue
+		}
+
+		count := len(points) - 1
+		if closed {
+			count = len(points)
+		}
+		normals := make([]mgl32.Vec2, count)
+		for i := range normals {
+			normals[i] = perp(points[(i+1)%len(points)].Sub(points[i])).Normalize()
+		}
+		previous := func(i int) mgl32.Vec2 {
+			if i > 0 {
+				return normals[i-1]
+			}
+			if closed {
+				return normals[count-1]
+			}
+			return normals[0]
+		}
+		next := func(i int) mgl32.Vec2 {
+			if i < count {
+				return normals[i]
+			}
+			if closed {
+				return normals[0]
+			}
+			return normals[count-1]
+		}
+
+		for i := 0; i < count; i++ {
+			j := i + 1
+			a, b := points[i], points[j%len(points)]
+			for _, side := range []float32{1, -1} {
+				inner := [2]fringeVertex{{pos: a, coverage: 1}, {pos: b, coverage: 1}}
+				outer := [2]fringeVertex{
+					{a, previous(i).Mul(side), next(i).Mul(side), 0},
+					{b, previous(j).Mul(side), next(j).Mul(side), 0},
+				}
+				vertices = append(vertices, inner[0], outer[0], inner[1], outer[0], outer[1], inner[1])
+			}
+		}
+	}
+	return vertices
+}
+
+func (path PathBuffer) fringeMesh() *fringeMesh {
+	mesh := path.fringe
+	if mesh == nil || (mesh.created && mesh.revision == path.revision) {
+		return mesh
+	}
+	if !mesh.created {
+		gl.CreateVertexArrays(1, &mesh.vao)
+		gl.BindVertexArray(mesh.vao)
+		mesh.vbo = CreateBuffer()
+		mesh.vbo.Bind()
+		gl.EnableVertexAttribArray(0)
+		gl.VertexAttribPointerWithOffset(0, 2, gl.FLOAT, false, int32(unsafe.Sizeof(fringeVertex{})), unsafe.Offsetof(fringeVertex{}.pos))
+		gl.EnableVertexAttribArray(1)
+		gl.VertexAttribPointerWithOffset(1, 2, gl.FLOAT, false, int32(unsafe.Sizeof(fringeVertex{})), unsafe.Offsetof(fringeVertex{}.previous))
+		gl.EnableVertexAttribArray(2)
+		gl.VertexAttribPointerWithOffset(2, 2, gl.FLOAT, false, int32(unsafe.Sizeof(fringeVertex{})), unsafe.Offsetof(fringeVertex{}.next))
+		gl.EnableVertexAttribArray(3)
+		gl.VertexAttribPointerWithOffset(3, 1, gl.FLOAT, false, int32(unsafe.Sizeof(fringeVertex{})), unsafe.Offsetof(fringeVertex{}.coverage))
+		mesh.created = true
+	}
+
+	vertices := fringeGeometry(path.polylines)
+	mesh.vbo.Bind()
+	if len(vertices) > 0 {
+		gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*int(unsafe.Sizeof(fringeVertex{})), unsafe.Pointer(&vertices[0]), gl.DYNAMIC_DRAW)
+	}
+	mesh.size = len(vertices)
+	mesh.revision = path.revision
+	return mesh
+}
+
+func (renderer *PathRenderer) drawFringe(path PathBuffer, transform mgl32.Mat3, paint Paint, width float32) {
+	mesh := path.fringeMesh()
+	if mesh == nil || mesh.size == 0 {
+		return
+	}
+	if width <= 0 {
+		width = DefaultFringeWidth
+	}
+	gl.BindVertexArray(mesh.vao)
+	renderer.fringeProgram.Bind(paint.bind(map[string]Uniform{
+		"transform": transform,
+		"viewport":  renderer.viewport,
+		"fringe":    width,
+	}, renderer.ramps))
+	gl.DrawArrays(gl.TRIANGLES, 0, int32(mesh.size))
+}
+
+func (mesh *fringeMesh) Delete() {
+	if mesh == nil || !mesh.created {
+		return
+	}
+	gl.DeleteVertexArrays(1, &mesh.vao)
+	mesh.vbo.Delete()
+	mesh.created = false
+}
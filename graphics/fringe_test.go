@@ -0,0 +1,42 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestFringeGeometrySharesCornerVertices(t *testing.T) {
+	square := Polyline{Points: []mgl32.Vec2{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}, Closed: true}
+	vertices := fringeGeometry([]Polyline{square})
+	if len(vertices) != 4*2*6 {
+		t.Fatalf("got %d vertices, want 48", len(vertices))
+	}
+
+	corners := map[mgl32.Vec2][]fringeVertex{}
+	for _, vertex := range vertices {
+		if vertex.coverage == 0 {
+			corners[vertex.pos] = append(corners[vertex.pos], vertex)
+		}
+	}
+	if len(corners) != 4 {
+		t.Fatalf("got outer vertices at %d corners, want 4", len(corners))
+	}
+	for corner, outer := range corners {
+		for _, vertex := range outer {
+			if vertex.previous.Dot(vertex.next) != 0 {
+				t.Errorf("corner %v extrudes along %v and %v, want both edge normals", corner, vertex.previous, vertex.next)
+			}
+			if vertex.previous != outer[0].previous && vertex.previous != outer[0].previous.Mul(-1) {
+				t.Errorf("corner %v has mismatched extrusions %v and %v", corner, vertex, outer[0])
+			}
+		}
+	}
+
+	open := fringeGeometry([]Polyline{{Points: []mgl32.Vec2{{0, 0}, {10, 0}, {10, 10}}}})
+	for _, vertex := range open {
+		if vertex.coverage == 0 && vertex.pos != (mgl32.Vec2{10, 0}) && vertex.previous != vertex.next {
+			t.Errorf("open end %v extrudes along %v and %v, want its only edge normal", vertex.pos, vertex.previous, vertex.next)
+		}
+	}
+}
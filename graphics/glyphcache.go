@@ -0,0 +1,212 @@
+package graphics
+
+import (
+	"image"
+	"unsafe"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+)
+
+type glyphCache struct {
+	ttf      *truetype.Font
+	face     font.Face
+	hiFace   font.Face
+	padding  int
+	pageSize int
+	maxPages int
+	pages    []*glyphPage
+	current  int
+	glyphs   map[rune]Character
+	missing  map[rune]bool
+	pass     int
+}
+
+type glyphPage struct {
+	texture         Texture
+	x, y, rowHeight int
+	lastUsed        int
+	pins            int
+	runes           []rune
+}
+
+func CreateDynamicFont(ttf *truetype.Font, size, padding, pageSize, maxPages int) Font {
+	if padding < 1 {
+		padding = 1
+	}
+	if maxPages < 1 {
+		maxPages = 1
+	}
+	face := truetype.NewFace(ttf, &truetype.Options{Size: float64(size), Hinting: font.HintingNone})
+	metrics := face.Metrics()
+	cache := &glyphCache{
+		ttf:      ttf,
+		face:     face,
+		hiFace:   truetype.NewFace(ttf, &truetype.Options{Size: float64(size * sdfSupersample), Hinting: font.HintingNone}),
+		padding:  padding,
+		pageSize: pageSize,
+		maxPages: maxPages,
+		glyphs:   make(map[rune]Character),
+		missing:  make(map[rune]bool),
+	}
+	data := FontData{
+		Name:       ttf.Name(truetype.NameIDFontFamily),
+		Size:       size,
+		Width:      pageSize,
+		Height:     pageSize,
+		LineHeight: metrics.Height.Round(),
+		Base:       metrics.Ascent.Round(),
+		Characters: map[string]Character{},
+	}
+	return Font{data: data, cache: cache}
+}
+
+func (cache *glyphCache) beginPass() {
+	cache.pass++
+}
+
+func (cache *glyphCache) character(r rune) (Character, bool) {
+	if char, ok := cache.glyphs[r]; ok {
+		cache.pages[char.Page].lastUsed = cache.pass
+		return char, true
+	}
+	if cache.missing[r] || cache.ttf.Index(r) == 0 {
+		return Character{}, false
+	}
+
+	advance, ok := cache.face.GlyphAdvance(r)
+	if !ok {
+		cache.missing[r] = true
+		return Character{}, false
+	}
+	glyph := bakeGlyph(cache.hiFace, r, cache.padding)
+	if glyph.width > cache.pageSize || glyph.height > cache.pageSize {
+		cache.missing[r] = true
+		return Character{}, false
+	}
+
+	pageIndex, x, y, ok := cache.allocate(glyph.width, glyph.height)
+	if !ok {
+		return Character{}, false
+	}
+	page := cache.pages[pageIndex]
+
+	pixels := make([]uint8, glyph.width*glyph.height*4)
+	for i, v := range glyph.pixels {
+		pixels[i*4+0] = v
+		pixels[i*4+1] = v
+		pixels[i*4+2] = v
+		pixels[i*4+3] = 255
+	}
+	gl.BindTexture(gl.TEXTURE_2D, page.texture.textureID)
+	gl.TexSubImage2D(gl.TEXTURE_2D, 0, int32(x), int32(y), int32(glyph.width), int32(glyph.height), gl.RGBA, gl.UNSIGNED_BYTE, unsafe.Pointer(&pixels[0]))
+
+	char := Character{
+		X:       x,
+		Y:       y,
+		Width:   glyph.width,
+		Height:  glyph.height,
+		OriginX: glyph.originX,
+		OriginY: glyph.originY,
+		Advance: advance.Round(),
+		Page:    pageIndex,
+	}
+	cache.glyphs[r] = char
+	page.runes = append(page.runes, r)
+	page.lastUsed = cache.pass
+	return char, true
+}
+
+func (cache *glyphCache) texture(page int) (Texture, bool) {
+	if page < 0 || page >= len(cache.pages) {
+		return Texture{}, false
+	}
+	return cache.pages[page].texture, true
+}
+
+func (cache *glyphCache) allocate(width, height int) (int, int, int, bool) {
+	if len(cache.pages) > 0 {
+		if x, y, ok := cache.pages[cache.current].pack(width, height, cache.pageSize); ok {
+			return cache.current, x, y, true
+		}
+	}
+
+	victim := -1
+	if len(cache.pages) >= cache.maxPages {
+		victim = cache.victim()
+	}
+	if victim < 0 {
+		texture := TextureFromRGBA(image.NewNRGBA(image.Rect(0, 0, cache.pageSize, cache.pageSize)))
+		cache.pages = append(cache.pages, &glyphPage{texture: texture, lastUsed: cache.pass})
+		cache.current = len(cache.pages) - 1
+		x, y, ok := cache.pages[cache.current].pack(width, height, cache.pageSize)
+		return cache.current, x, y, ok
+	}
+
+	page := cache.pages[victim]
+	for _, r := range page.runes {
+		delete(cache.glyphs, r)
+	}
+	page.runes = page.runes[:0]
+	page.x, page.y, page.rowHeight = 0, 0, 0
+	pixels := make([]uint8, cache.pageSize*cache.pageSize*4)
+	gl.BindTexture(gl.TEXTURE_2D, page.texture.textureID)
+	gl.TexSubImage2D(gl.TEXTURE_2D, 0, 0, 0, int32(cache.pageSize), int32(cache.pageSize), gl.RGBA, gl.UNSIGNED_BYTE, unsafe.Pointer(&pixels[0]))
+
+	cache.current = victim
+	x, y, ok := page.pack(width, height, cache.pageSize)
+	return victim, x, y, ok
+}
+
+func (cache *glyphCache) victim() int {
+	victim := -1
+	for i, page := range cache.pages {
+		if page.pins > 0 || page.lastUsed == cache.pass {
+			continue
+		}
+		if victim < 0 || page.lastUsed < cache.pages[victim].lastUsed {
+			victim = i
+		}
+	}
+	return victim
+}
+
+func (cache *glyphCache) pin(textures []Texture, delta int) {
+	for _, page := range cache.pages {
+		for _, texture := range textures {
+			if page.texture == texture {
+				page.pins += delta
+			}
+		}
+	}
+}
+
+func (page *glyphPage) pack(width, height, size int) (int, int, bool) {
+	if page.x+width > size {
+		page.x = 0
+		page.y += page.rowHeight
+		page.rowHeight = 0
+	}
+	if page.y+height > size {
+		return 0, 0, false
+	}
+	x, y := page.x, page.y
+	page.x += width
+	if height > page.rowHeight {
+		page.rowHeight = height
+	}
+	return x, y, true
+}
+
+func (cache *glyphCache) kerning(prev, r rune) int {
+	return cache.face.Kern(prev, r).Round()
+}
+
+func (cache *glyphCache) Delete() {
+	for _, page := range cache.pages {
+		page.texture.Delete()
+	}
+	cache.pages = nil
+	cache.glyphs = make(map[rune]Character)
+}
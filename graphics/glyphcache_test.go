@@ -0,0 +1,33 @@
+package graphics
+
+import "testing"
+
+func TestGlyphCacheVictim(t *testing.T) {
+	cache := &glyphCache{pass: 5}
+	for i := 0; i < 3; i++ {
+		cache.pages = append(cache.pages, &glyphPage{texture: Texture{textureID: uint32(i + 1)}})
+	}
+	cache.pages[0].lastUsed = 2
+	cache.pages[1].lastUsed = 1
+	cache.pages[2].lastUsed = 5
+
+	if victim := cache.victim(); victim != 1 {
+		t.Errorf("got victim %d, want the least recently used page 1", victim)
+	}
+
+	pinned := []Texture{cache.pages[1].texture}
+	cache.pin(pinned, 1)
+	if victim := cache.victim(); victim != 0 {
+		t.Errorf("got victim %d with page 1 pinned, want 0", victim)
+	}
+
+	cache.pin([]Texture{cache.pages[0].texture}, 1)
+	if victim := cache.victim(); victim != -1 {
+		t.Errorf("got victim %d with every page pinned or in use, want -1", victim)
+	}
+
+	cache.pin(pinned, -1)
+	if victim := cache.victim(); victim != 1 {
+		t.Errorf("got victim %d after unpinning page 1, want 1", victim)
+	}
+}
@@ -0,0 +1,518 @@
+package graphics
+
+import (
+	"fmt"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+type Alignment int
+
+// AlignLeft is the zero value and stays left-aligned for RTL paragraphs;
+// use AlignStart, as DefaultLayoutOptions does, to follow the paragraph direction.
+const (
+	AlignLeft Alignment = iota
+	AlignCenter
+	AlignRight
+	AlignStart
+	AlignEnd
+)
+
+const iconRune = '\uFFFC'
+
+type LayoutOptions struct {
+	MaxWidth    float32
+	LineSpacing float32
+	Align       Alignment
+	Direction   TextDirection
+}
+
+var DefaultLayoutOptions = LayoutOptions{Align: AlignStart}
+
+type GlyphBounds struct {
+	Rune     rune
+	Line     int
+	RTL      bool
+	Min, Max mgl32.Vec2
+}
+
+type LineBounds struct {
+	Start, End int
+	Baseline   float32
+	RTL        bool
+	Min, Max   mgl32.Vec2
+}
+
+type TextLayout struct {
+	Glyphs   []GlyphBounds
+	Lines    []LineBounds
+	Min, Max mgl32.Vec2
+}
+
+type layoutItem struct {
+	r         rune
+	run       int
+	advance   float32
+	kerning   float32
+	character Character
+	texture   Texture
+	icon      Icon
+	isIcon    bool
+	hasGlyph  bool
+	scale     float32
+	mode      int
+	level     int
+	base      int
+}
+
+func MeasureString(data FontData, str string, options LayoutOptions) TextLayout {
+	return NewFont(data).Measure(str, options)
+}
+
+func (font Font) Measure(str string, options LayoutOptions) TextLayout {
+	layout, _, _ := layoutText(font, IconAtlas{}, []TextRun{{Text: str, Style: DefaultTextStyle}}, options)
+	return layout
+}
+
+func hasSize(texture Texture) bool {
+	return texture.width > 0 && texture.height > 0
+}
+
+func (font Font) ascent() float32 {
+	if font.data.Base > 0 {
+		return float32(font.data.Base)
+	}
+	return float32(font.LineHeight()) * 0.8
+}
+
+func bidiLevels(runes []rune, direction TextDirection) ([]bidiClass, []int, []int) {
+	classes := make([]bidiClass, len(runes))
+	for i, r := range runes {
+		classes[i] = classifyBidi(r)
+	}
+
+	levels := make([]int, len(runes))
+	bases := make([]int, len(runes))
+	for start := 0; start < len(runes); {
+		end := start
+		for end < len(runes) && runes[end] != '\n' {
+			end++
+		}
+		if end < len(runes) {
+			end++
+		}
+		base := paragraphLevel(classes[start:end], direction)
+		copy(levels[start:end], resolveLevels(classes[start:end], base))
+		for i := start; i < end; i++ {
+			bases[i] = base
+		}
+		start = end
+	}
+	return classes, levels, bases
+}
+
+func layoutText(font Font, icons IconAtlas, runs []TextRun, options LayoutOptions) (TextLayout, []glyphQuad, error) {
+	runes := []rune{}
+	for _, run := range runs {
+		if run.Icon != "" {
+			runes = append(runes, iconRune)
+			continue
+		}
+		runes = append(runes, []rune(run.Text)...)
+	}
+	shaped, ligated := shapeArabic(runes, font.HasRune)
+	classes, levels, bases := bidiLevels(runes, options.Direction)
+
+	items := []layoutItem{}
+	prev := rune(-1)
+	prevSource := -1
+	chain := font.chain()
+	font.beginLayout()
+
+	for i, run := range runs {
+		if run.Icon != "" {
+			icon, ok := icons.icons[run.Icon]
+			if !ok {
+				return TextLayout{}, nil, fmt.Errorf("unknown icon '%s'", run.Icon)
+			}
+			k := len(items)
+			height := float32(font.data.Size)
+			width := height * float32(icon.Width) / float32(icon.Height)
+			items = append(items, layoutItem{r: iconRune, run: i, advance: width, icon: icon, texture: icons.texture, isIcon: true, level: levels[k], base: bases[k]})
+			prev = -1
+			continue
+		}
+
+		for _, r := range run.Text {
+			k := len(items)
+			item := layoutItem{r: r, run: i, level: levels[k], base: bases[k]}
+			glyph := shaped[k]
+			if mirror, ok := bidiMirrors[glyph]; ok && item.level%2 == 1 && font.HasRune(mirror) {
+				glyph = mirror
+			}
+			source := -1
+			if r != '\n' && !ligated[k] {
+				for j, f := range chain {
+					if char, ok := f.character(glyph); ok {
+						source = j
+						item.character = char
+						break
+					}
+				}
+			}
+			if source >= 0 {
+				f := chain[source]
+				item.scale = 1
+				if f.data.Size > 0 && font.data.Size > 0 {
+					item.scale = float32(font.data.Size) / float32(f.data.Size)
+				}
+				item.mode = textModeSDF
+				if f.data.Bitmap {
+					item.mode = textModeBitmap
+				}
+				item.advance = float32(item.character.Advance) * item.scale
+				item.hasGlyph = true
+				if texture, ok := f.texture(item.character); ok {
+					item.texture = texture
+				}
+				if prev >= 0 && prevSource == source && item.level%2 == 0 && items[k-1].level == item.level {
+					item.kerning = float32(f.kerning(prev, glyph)) * item.scale
+				}
+				prev = glyph
+			} else {
+				prev = -1
+			}
+			prevSource = source
+			items = append(items, item)
+		}
+	}
+
+	lines := breakLines(items, options.MaxWidth)
+
+	spacing := options.LineSpacing
+	if spacing == 0 {
+		spacing = 1
+	}
+	lineAdvance := float32(font.LineHeight()) * spacing
+	ascent := font.ascent()
+	descent := float32(font.LineHeight()) - ascent
+
+	widths := make([]float32, len(lines))
+	maxWidth := options.MaxWidth
+	for i, line := range lines {
+		widths[i] = lineWidth(items, line[0], line[1])
+		if options.MaxWidth == 0 && widths[i] > maxWidth {
+			maxWidth = widths[i]
+		}
+	}
+
+	layout := TextLayout{Glyphs: make([]GlyphBounds, len(items))}
+	slots := make([]glyphQuad, len(items))
+	filled := make([]bool, len(items))
+
+	for l, line := range lines {
+		baseline := float32(-l) * lineAdvance
+		base := 0
+		if line[1] > line[0] {
+			base = items[line[0]].base
+		} else if line[0] > 0 {
+			base = items[line[0]-1].base
+		} else {
+			base = paragraphLevel(nil, options.Direction)
+		}
+		rtl := base%2 == 1
+
+		align := options.Align
+		switch align {
+		case AlignStart:
+			align = AlignLeft
+			if rtl {
+				align = AlignRight
+			}
+		case AlignEnd:
+			align = AlignRight
+			if rtl {
+				align = AlignLeft
+			}
+		}
+		left := float32(0)
+		switch align {
+		case AlignCenter:
+			left = (maxWidth - widths[l]) / 2
+		case AlignRight:
+			left = maxWidth - widths[l]
+		}
+
+		lineBounds := LineBounds{
+			Start:    line[0],
+			End:      line[1],
+			Baseline: baseline,
+			RTL:      rtl,
+			Min:      mgl32.Vec2{left, baseline - descent},
+			Max:      mgl32.Vec2{left + widths[l], baseline + ascent},
+		}
+
+		visual := lineLevels(levels, classes, line[0], line[1], base)
+		order := visualOrder(visual)
+		if rtl {
+			for i := line[1] - 1; i >= line[0] && isSpace(items[i].r); i-- {
+				left -= items[i].advance
+			}
+		}
+
+		for v, o := range order {
+			i := line[0] + o
+			item := items[i]
+			if v > 0 && order[v-1] == o-1 {
+				left += item.kerning
+			}
+			layout.Glyphs[i] = GlyphBounds{
+				Rune: item.r,
+				Line: l,
+				RTL:  visual[o]%2 == 1,
+				Min:  mgl32.Vec2{left, baseline - descent},
+				Max:  mgl32.Vec2{left + item.advance, baseline + ascent},
+			}
+
+			style := runs[item.run].Style
+			threshold := float32(0.5)
+			if style.Bold {
+				threshold = 0.4
+			}
+			shear := float32(0)
+			if style.Italic {
+				shear = 0.2
+			}
+
+			if item.isIcon && hasSize(item.texture) {
+				texture := item.texture
+				icon := item.icon
+				bottom := baseline - float32(font.data.Size)*0.2
+				slots[i] = glyphQuad{
+					min:      mgl32.Vec2{left, bottom},
+					max:      mgl32.Vec2{left + item.advance, bottom + float32(font.data.Size)},
+					texMin:   mgl32.Vec2{float32(icon.X) / float32(texture.width), float32(icon.Y+icon.Height) / float32(texture.height)},
+					texMax:   mgl32.Vec2{float32(icon.X+icon.Width) / float32(texture.width), float32(icon.Y) / float32(texture.height)},
+					texture:  texture,
+					mode:     textModeImage,
+					color:    mgl32.Vec4{1, 1, 1, style.Color.W()},
+					baseline: baseline,
+					r:        item.r,
+					source:   i,
+					effect:   style.Effect,
+				}
+				filled[i] = true
+			} else if item.hasGlyph && hasSize(item.texture) {
+				char := item.character
+				texture := item.texture
+				x := left - float32(char.OriginX)*item.scale
+				y := baseline + float32(char.OriginY)*item.scale
+				slots[i] = glyphQuad{
+					min:       mgl32.Vec2{x, y - float32(char.Height)*item.scale},
+					max:       mgl32.Vec2{x + float32(char.Width)*item.scale, y},
+					texMin:    mgl32.Vec2{float32(char.X) / float32(texture.width), float32(char.Y+char.Height) / float32(texture.height)},
+					texMax:    mgl32.Vec2{float32(char.X+char.Width) / float32(texture.width), float32(char.Y) / float32(texture.height)},
+					texture:   texture,
+					mode:      item.mode,
+					color:     style.Color,
+					threshold: threshold,
+					shear:     shear,
+					baseline:  baseline,
+					r:         item.r,
+					source:    i,
+					effect:    style.Effect,
+				}
+				filled[i] = true
+			}
+
+			left += item.advance
+		}
+
+		layout.Lines = append(layout.Lines, lineBounds)
+	}
+
+	quads := []glyphQuad{}
+	for i, quad := range slots {
+		if filled[i] {
+			quads = append(quads, quad)
+		}
+	}
+
+	for i, line := range layout.Lines {
+		if i == 0 {
+			layout.Min, layout.Max = line.Min, line.Max
+			continue
+		}
+		layout.Min = mgl32.Vec2{min32(layout.Min.X(), line.Min.X()), min32(layout.Min.Y(), line.Min.Y())}
+		layout.Max = mgl32.Vec2{max32(layout.Max.X(), line.Max.X()), max32(layout.Max.Y(), line.Max.Y())}
+	}
+
+	return layout, quads, nil
+}
+
+func lineWidth(items []layoutItem, start, end int) float32 {
+	width := float32(0)
+	for i := start; i < end; i++ {
+		if i > start {
+			width += items[i].kerning
+		}
+		width += items[i].advance
+	}
+	for i := end - 1; i >= start && isSpace(items[i].r); i-- {
+		width -= items[i].advance
+	}
+	return width
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n'
+}
+
+func breakLines(items []layoutItem, maxWidth float32) [][2]int {
+	lines := [][2]int{}
+	start := 0
+	width := float32(0)
+	lastBreak := -1
+
+	for i := 0; i < len(items); i++ {
+		item := items[i]
+		if item.r == '\n' {
+			lines = append(lines, [2]int{start, i + 1})
+			start = i + 1
+			width = 0
+			lastBreak = -1
+			continue
+		}
+
+		advance := item.advance
+		if i > start {
+			advance += item.kerning
+		}
+
+		if maxWidth > 0 && !isSpace(item.r) && width+advance > maxWidth && i > start {
+			end := i
+			if lastBreak >= start {
+				end = lastBreak + 1
+			}
+			lines = append(lines, [2]int{start, end})
+			start = end
+			lastBreak = -1
+			width = 0
+			for j := start; j <= i; j++ {
+				if j > start {
+					width += items[j].kerning
+				}
+				width += items[j].advance
+			}
+			continue
+		}
+
+		if isSpace(item.r) {
+			lastBreak = i
+		}
+		width += advance
+	}
+
+	lines = append(lines, [2]int{start, len(items)})
+	return lines
+}
+
+func (layout TextLayout) HitTest(point mgl32.Vec2) int {
+	if len(layout.Lines) == 0 {
+		return 0
+	}
+
+	line := layout.Lines[len(layout.Lines)-1]
+	for _, l := range layout.Lines {
+		if point.Y() >= l.Min.Y() {
+			line = l
+			break
+		}
+	}
+
+	end := line.End
+	if end > line.Start && end < len(layout.Glyphs) && isSpace(layout.Glyphs[end-1].Rune) {
+		end--
+	}
+	if end == line.Start {
+		return end
+	}
+
+	leftmost, rightmost := -1, -1
+	for i := line.Start; i < end; i++ {
+		glyph := layout.Glyphs[i]
+		if glyph.Max.X() <= glyph.Min.X() {
+			continue
+		}
+		if point.X() >= glyph.Min.X() && point.X() < glyph.Max.X() {
+			before := point.X() < (glyph.Min.X()+glyph.Max.X())/2
+			if before != glyph.RTL {
+				return i
+			}
+			return i + 1
+		}
+		if leftmost < 0 || glyph.Min.X() < layout.Glyphs[leftmost].Min.X() {
+			leftmost = i
+		}
+		if rightmost < 0 || glyph.Max.X() > layout.Glyphs[rightmost].Max.X() {
+			rightmost = i
+		}
+	}
+	if leftmost < 0 {
+		return end
+	}
+
+	if point.X() < layout.Glyphs[leftmost].Min.X() {
+		if layout.Glyphs[leftmost].RTL {
+			return leftmost + 1
+		}
+		return leftmost
+	}
+	if layout.Glyphs[rightmost].RTL {
+		return rightmost
+	}
+	return rightmost + 1
+}
+
+func (layout TextLayout) CaretPosition(index int) mgl32.Vec2 {
+	if len(layout.Lines) == 0 {
+		return mgl32.Vec2{0, 0}
+	}
+	if index < 0 {
+		index = 0
+	}
+	if index < len(layout.Glyphs) {
+		glyph := layout.Glyphs[index]
+		if glyph.RTL {
+			return mgl32.Vec2{glyph.Max.X(), layout.Lines[glyph.Line].Baseline}
+		}
+		return mgl32.Vec2{glyph.Min.X(), layout.Lines[glyph.Line].Baseline}
+	}
+
+	line := layout.Lines[len(layout.Lines)-1]
+	if line.End > line.Start {
+		glyph := layout.Glyphs[line.End-1]
+		if glyph.RTL {
+			return mgl32.Vec2{glyph.Min.X(), line.Baseline}
+		}
+		return mgl32.Vec2{glyph.Max.X(), line.Baseline}
+	}
+	if line.RTL {
+		return mgl32.Vec2{line.Max.X(), line.Baseline}
+	}
+	return mgl32.Vec2{line.Min.X(), line.Baseline}
+}
+
+func min32(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max32(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,215 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func testFontData() FontData {
+	data := FontData{
+		Size:       10,
+		LineHeight: 12,
+		Base:       9,
+		Characters: map[string]Character{" ": {Advance: 5}},
+		Kerning:    map[string]int{"ef": -2},
+	}
+	for r := 'a'; r <= 'z'; r++ {
+		data.Characters[string(r)] = Character{Width: 8, Height: 9, OriginY: 9, Advance: 10}
+	}
+	return data
+}
+
+func testBidiFontData() FontData {
+	data := testFontData()
+	for r := 'א'; r <= 'ת'; r++ {
+		data.Characters[string(r)] = Character{Width: 8, Height: 9, OriginY: 9, Advance: 10}
+	}
+	for r := '0'; r <= '9'; r++ {
+		data.Characters[string(r)] = Character{Width: 5, Height: 9, OriginY: 9, Advance: 6}
+	}
+	data.Characters["("] = Character{Width: 3, Height: 9, OriginY: 9, Advance: 3}
+	data.Characters[")"] = Character{Width: 4, Height: 9, OriginY: 9, Advance: 4}
+	return data
+}
+
+func TestMeasureString(t *testing.T) {
+	layout := MeasureString(testFontData(), "ab cd", LayoutOptions{})
+	if len(layout.Lines) != 1 || len(layout.Glyphs) != 5 {
+		t.Fatalf("got %d lines and %d glyphs, want 1 and 5", len(layout.Lines), len(layout.Glyphs))
+	}
+	lefts := []float32{0, 10, 20, 25, 35}
+	for i, left := range lefts {
+		if layout.Glyphs[i].Min.X() != left {
+			t.Errorf("glyph %d starts at %v, want %v", i, layout.Glyphs[i].Min.X(), left)
+		}
+	}
+	if !layout.Min.ApproxEqual(mgl32.Vec2{0, -3}) || !layout.Max.ApproxEqual(mgl32.Vec2{45, 9}) {
+		t.Errorf("got bounds %v %v, want [0 -3] [45 9]", layout.Min, layout.Max)
+	}
+
+	kerned := MeasureString(testFontData(), "ef", LayoutOptions{})
+	if kerned.Glyphs[1].Min.X() != 8 {
+		t.Errorf("kerned glyph starts at %v, want 8", kerned.Glyphs[1].Min.X())
+	}
+
+	centered := MeasureString(testFontData(), "ab", LayoutOptions{MaxWidth: 40, Align: AlignCenter})
+	if centered.Glyphs[0].Min.X() != 10 {
+		t.Errorf("centered glyph starts at %v, want 10", centered.Glyphs[0].Min.X())
+	}
+}
+
+func TestLayoutWithoutTextures(t *testing.T) {
+	layout, quads, err := layoutText(NewFont(testFontData()), IconAtlas{}, []TextRun{{Text: "abc", Style: DefaultTextStyle}}, LayoutOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quads) != 0 {
+		t.Errorf("got %d quads without textures, want 0", len(quads))
+	}
+	if layout.Max.X() != 30 {
+		t.Errorf("got width %v, want 30", layout.Max.X())
+	}
+}
+
+func TestWrappedLayout(t *testing.T) {
+	layout := MeasureString(testFontData(), "ab cd", LayoutOptions{MaxWidth: 30})
+	if len(layout.Lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(layout.Lines))
+	}
+	if layout.Lines[0].Start != 0 || layout.Lines[0].End != 3 || layout.Lines[1].Start != 3 || layout.Lines[1].End != 5 {
+		t.Errorf("got lines %v, want [0,3) and [3,5)", layout.Lines)
+	}
+	if layout.Lines[1].Baseline != -12 {
+		t.Errorf("got second baseline %v, want -12", layout.Lines[1].Baseline)
+	}
+
+	hits := []struct {
+		point mgl32.Vec2
+		index int
+	}{
+		{mgl32.Vec2{-5, 0}, 0},
+		{mgl32.Vec2{12, 5}, 1},
+		{mgl32.Vec2{18, 5}, 2},
+		{mgl32.Vec2{50, 0}, 2},
+		{mgl32.Vec2{2, -12}, 3},
+		{mgl32.Vec2{12, -12}, 4},
+		{mgl32.Vec2{50, -12}, 5},
+		{mgl32.Vec2{2, -100}, 3},
+	}
+	for _, hit := range hits {
+		if index := layout.HitTest(hit.point); index != hit.index {
+			t.Errorf("HitTest(%v) = %d, want %d", hit.point, index, hit.index)
+		}
+	}
+
+	carets := []struct {
+		index int
+		point mgl32.Vec2
+	}{
+		{0, mgl32.Vec2{0, 0}},
+		{2, mgl32.Vec2{20, 0}},
+		{3, mgl32.Vec2{0, -12}},
+		{4, mgl32.Vec2{10, -12}},
+		{5, mgl32.Vec2{20, -12}},
+	}
+	for _, caret := range carets {
+		if point := layout.CaretPosition(caret.index); !point.ApproxEqual(caret.point) {
+			t.Errorf("CaretPosition(%d) = %v, want %v", caret.index, point, caret.point)
+		}
+	}
+}
+
+func TestMultiLineLayout(t *testing.T) {
+	layout := MeasureString(testFontData(), "ab\ncd\n", LayoutOptions{})
+	if len(layout.Lines) != 3 {
+		t.Fatalf("got %d lines, want 3", len(layout.Lines))
+	}
+	if !layout.Min.ApproxEqual(mgl32.Vec2{0, -27}) || !layout.Max.ApproxEqual(mgl32.Vec2{20, 9}) {
+		t.Errorf("got bounds %v %v, want [0 -27] [20 9]", layout.Min, layout.Max)
+	}
+
+	hits := []struct {
+		point mgl32.Vec2
+		index int
+	}{
+		{mgl32.Vec2{100, 0}, 2},
+		{mgl32.Vec2{3, -12}, 3},
+		{mgl32.Vec2{100, -12}, 5},
+		{mgl32.Vec2{100, -24}, 6},
+	}
+	for _, hit := range hits {
+		if index := layout.HitTest(hit.point); index != hit.index {
+			t.Errorf("HitTest(%v) = %d, want %d", hit.point, index, hit.index)
+		}
+	}
+
+	carets := []struct {
+		index int
+		point mgl32.Vec2
+	}{
+		{2, mgl32.Vec2{20, 0}},
+		{3, mgl32.Vec2{0, -12}},
+		{5, mgl32.Vec2{20, -12}},
+		{6, mgl32.Vec2{0, -24}},
+	}
+	for _, caret := range carets {
+		if point := layout.CaretPosition(caret.index); !point.ApproxEqual(caret.point) {
+			t.Errorf("CaretPosition(%d) = %v, want %v", caret.index, point, caret.point)
+		}
+	}
+}
+
+func TestRTLLayout(t *testing.T) {
+	tests := []struct {
+		text   string
+		lefts  []float32
+		hits   map[float32]int
+		carets map[int]float32
+	}{
+		{
+			"אבג",
+			[]float32{20, 10, 0},
+			map[float32]int{-5: 3, 2: 3, 8: 2, 22: 1, 28: 0, 40: 0},
+			map[int]float32{0: 30, 1: 20, 2: 10, 3: 0},
+		},
+		{
+			"אב 12",
+			[]float32{27, 17, 12, 0, 6},
+			map[float32]int{2: 3, 4: 4, 9: 5, 14: 3, 30: 1, 36: 0},
+			map[int]float32{0: 37, 1: 27, 2: 17, 3: 0, 4: 6, 5: 12},
+		},
+	}
+	for _, test := range tests {
+		layout := MeasureString(testBidiFontData(), test.text, DefaultLayoutOptions)
+		if len(layout.Lines) != 1 || !layout.Lines[0].RTL {
+			t.Fatalf("%q: got %d lines, want a single RTL line", test.text, len(layout.Lines))
+		}
+		for i, left := range test.lefts {
+			if layout.Glyphs[i].Min.X() != left {
+				t.Errorf("%q: glyph %d starts at %v, want %v", test.text, i, layout.Glyphs[i].Min.X(), left)
+			}
+		}
+		for x, index := range test.hits {
+			if got := layout.HitTest(mgl32.Vec2{x, 0}); got != index {
+				t.Errorf("%q: HitTest(%v) = %d, want %d", test.text, x, got, index)
+			}
+		}
+		for index, x := range test.carets {
+			if point := layout.CaretPosition(index); !point.ApproxEqual(mgl32.Vec2{x, 0}) {
+				t.Errorf("%q: CaretPosition(%d) = %v, want [%v 0]", test.text, index, point, x)
+			}
+		}
+	}
+}
+
+func TestRTLDefaultAlignment(t *testing.T) {
+	layout := MeasureString(testBidiFontData(), "אב\nא", DefaultLayoutOptions)
+	if layout.Glyphs[3].Min.X() != 10 {
+		t.Errorf("default options start the short RTL line at %v, want 10", layout.Glyphs[3].Min.X())
+	}
+	left := MeasureString(testBidiFontData(), "אב\nא", LayoutOptions{Align: AlignLeft})
+	if left.Glyphs[3].Min.X() != 0 {
+		t.Errorf("AlignLeft starts the short RTL line at %v, want 0", left.Glyphs[3].Min.X())
+	}
+}
@@ -0,0 +1,187 @@
+package graphics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+type TextStyle struct {
+	Color  mgl32.Vec4
+	Bold   bool
+	Italic bool
+	Effect GlyphEffect
+}
+
+type TextRun struct {
+	Text  string
+	Icon  string
+	Style TextStyle
+}
+
+var DefaultTextStyle = TextStyle{Color: mgl32.Vec4{0, 0, 0, 1}}
+
+var namedColors = map[string]mgl32.Vec4{
+	"black":  {0, 0, 0, 1},
+	"white":  {1, 1, 1, 1},
+	"red":    {1, 0, 0, 1},
+	"green":  {0, 1, 0, 1},
+	"blue":   {0, 0, 1, 1},
+	"yellow": {1, 1, 0, 1},
+	"cyan":   {0, 1, 1, 1},
+	"purple": {1, 0, 1, 1},
+	"orange": {1, 0.5, 0, 1},
+	"gray":   {0.5, 0.5, 0.5, 1},
+}
+
+var markupEffects = map[string]GlyphEffect{
+	"wave":    WaveEffect(8, 0.5, 6),
+	"shake":   ShakeEffect(3, 20),
+	"rainbow": RainbowEffect(0.5, 0.1),
+}
+
+type markupTag struct {
+	name  string
+	style TextStyle
+}
+
+func ParseMarkup(markup string) ([]TextRun, error) {
+	return ParseMarkupWithStyle(markup, DefaultTextStyle)
+}
+
+func ParseMarkupWithStyle(markup string, style TextStyle) ([]TextRun, error) {
+	runs := []TextRun{}
+	stack := []markupTag{}
+	text := strings.Builder{}
+
+	flush := func() {
+		if text.Len() > 0 {
+			runs = append(runs, TextRun{Text: text.String(), Style: style})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(markup); {
+		c := markup[i]
+		if c != '[' {
+			text.WriteByte(c)
+			i++
+			continue
+		}
+		if strings.HasPrefix(markup[i:], "[[") {
+			text.WriteByte('[')
+			i += 2
+			continue
+		}
+
+		end := strings.IndexByte(markup[i:], ']')
+		if end < 0 {
+			return nil, fmt.Errorf("markup: unterminated tag at offset %d", i)
+		}
+		tag := markup[i+1 : i+end]
+		offset := i
+		i += end + 1
+
+		if strings.HasPrefix(tag, "/") {
+			name := tag[1:]
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("markup: unexpected [/%s] at offset %d", name, offset)
+			}
+			open := stack[len(stack)-1]
+			if open.name != name {
+				return nil, fmt.Errorf("markup: unexpected [/%s] at offset %d, expected [/%s]", name, offset, open.name)
+			}
+			flush()
+			style = open.style
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		name, value := tag, ""
+		hasValue := false
+		if eq := strings.IndexByte(tag, '='); eq >= 0 {
+			name, value = tag[:eq], tag[eq+1:]
+			hasValue = true
+		}
+
+		switch name {
+		case "icon":
+			if value == "" {
+				return nil, fmt.Errorf("markup: [icon] at offset %d needs a name", offset)
+			}
+			flush()
+			runs = append(runs, TextRun{Icon: value, Style: style})
+			continue
+		case "color":
+			color, err := ParseColor(value)
+			if err != nil {
+				return nil, fmt.Errorf("markup: [color] at offset %d: %v", offset, err)
+			}
+			flush()
+			stack = append(stack, markupTag{name, style})
+			style.Color = color
+		case "wave", "shake", "rainbow":
+			if hasValue {
+				return nil, fmt.Errorf("markup: [%s] at offset %d does not take a value", name, offset)
+			}
+			flush()
+			stack = append(stack, markupTag{name, style})
+			style.Effect = markupEffects[name]
+		case "b", "i":
+			if hasValue {
+				return nil, fmt.Errorf("markup: [%s] at offset %d does not take a value", name, offset)
+			}
+			flush()
+			stack = append(stack, markupTag{name, style})
+			if name == "b" {
+				style.Bold = true
+			} else {
+				style.Italic = true
+			}
+		default:
+			return nil, fmt.Errorf("markup: unknown tag [%s] at offset %d", name, offset)
+		}
+	}
+
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("markup: unclosed tag [%s]", stack[len(stack)-1].name)
+	}
+	flush()
+
+	return runs, nil
+}
+
+func ParseColor(str string) (mgl32.Vec4, error) {
+	if color, ok := namedColors[str]; ok {
+		return color, nil
+	}
+	if !strings.HasPrefix(str, "#") {
+		return mgl32.Vec4{}, fmt.Errorf("invalid color '%s'", str)
+	}
+	hex := str[1:]
+	if len(hex) == 3 || len(hex) == 4 {
+		expanded := make([]byte, 0, len(hex)*2)
+		for i := range hex {
+			expanded = append(expanded, hex[i], hex[i])
+		}
+		hex = string(expanded)
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return mgl32.Vec4{}, fmt.Errorf("invalid color '%s'", str)
+	}
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return mgl32.Vec4{}, fmt.Errorf("invalid color '%s'", str)
+	}
+	return mgl32.Vec4{
+		float32(value>>24&0xff) / 255,
+		float32(value>>16&0xff) / 255,
+		float32(value>>8&0xff) / 255,
+		float32(value&0xff) / 255,
+	}, nil
+}
@@ -0,0 +1,266 @@
+package graphics
+
+import (
+	"math"
+	"sort"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+type PathMorph struct {
+	from, to []Polyline
+}
+
+type PathAnimation struct {
+	buffer   *PathBuffer
+	morph    PathMorph
+	duration float32
+	elapsed  float32
+	easing   func(t float32) float32
+}
+
+func CreatePathMorph(from, to *Path, samples int) PathMorph {
+	a := from.Flatten(from.flatTolerance())
+	b := to.Flatten(to.flatTolerance())
+	morph := PathMorph{}
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var source, target Polyline
+		switch {
+		case i >= len(a):
+			target = b[i]
+			source = collapsePolyline(target)
+		case i >= len(b):
+			source = a[i]
+			target = collapsePolyline(source)
+		default:
+			source, target = a[i], b[i]
+		}
+
+		closed := source.Closed && target.Closed
+		sourceLoop := loopPoints(source, closed)
+		targetLoop := loopPoints(target, closed)
+		if closed {
+			if polylineArea(source.Points)*polylineArea(target.Points) < 0 {
+				targetLoop = reversePoints(targetLoop)
+			}
+			targetLoop = rotateLoop(targetLoop, alignLoops(sourceLoop, targetLoop, maxInt(samples, 64)))
+		}
+
+		positions := mergePositions(arcPositions(sourceLoop), arcPositions(targetLoop), samples, closed)
+		morph.from = append(morph.from, Polyline{Points: samplePositions(sourceLoop, positions), Closed: closed})
+		morph.to = append(morph.to, Polyline{Points: samplePositions(targetLoop, positions), Closed: closed})
+	}
+	return morph
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func collapsePolyline(polyline Polyline) Polyline {
+	center := mgl32.Vec2{}
+	for _, point := range polyline.Points {
+		center = center.Add(point)
+	}
+	if len(polyline.Points) > 0 {
+		center = center.Mul(1 / float32(len(polyline.Points)))
+	}
+	return Polyline{Points: []mgl32.Vec2{center, center}, Closed: polyline.Closed}
+}
+
+func polylineArea(points []mgl32.Vec2) float32 {
+	area := float32(0)
+	for i, a := range points {
+		b := points[(i+1)%len(points)]
+		area += a.X()*b.Y() - b.X()*a.Y()
+	}
+	return area / 2
+}
+
+func loopPoints(polyline Polyline, closed bool) []mgl32.Vec2 {
+	points := append([]mgl32.Vec2{}, polyline.Points...)
+	if closed && len(points) > 1 && !points[0].ApproxEqual(points[len(points)-1]) {
+		points = append(points, points[0])
+	}
+	return points
+}
+
+func reversePoints(points []mgl32.Vec2) []mgl32.Vec2 {
+	reversed := make([]mgl32.Vec2, len(points))
+	for i, point := range points {
+		reversed[len(points)-1-i] = point
+	}
+	return reversed
+}
+
+func arcLengths(points []mgl32.Vec2) []float32 {
+	lengths := make([]float32, len(points))
+	for i := 1; i < len(points); i++ {
+		lengths[i] = lengths[i-1] + points[i].Sub(points[i-1]).Len()
+	}
+	return lengths
+}
+
+func arcPositions(points []mgl32.Vec2) []float32 {
+	lengths := arcLengths(points)
+	total := lengths[len(lengths)-1]
+	if total == 0 {
+		return []float32{0}
+	}
+	for i := range lengths {
+		lengths[i] /= total
+	}
+	return lengths
+}
+
+func pointAtLength(points []mgl32.Vec2, lengths []float32, distance float32) mgl32.Vec2 {
+	for i := 1; i < len(points); i++ {
+		if distance <= lengths[i] {
+			span := lengths[i] - lengths[i-1]
+			if span <= 0 {
+				return points[i]
+			}
+			return points[i-1].Add(points[i].Sub(points[i-1]).Mul((distance - lengths[i-1]) / span))
+		}
+	}
+	return points[len(points)-1]
+}
+
+func samplePositions(points []mgl32.Vec2, positions []float32) []mgl32.Vec2 {
+	lengths := arcLengths(points)
+	total := lengths[len(lengths)-1]
+	result := make([]mgl32.Vec2, len(positions))
+	for i, position := range positions {
+		result[i] = pointAtLength(points, lengths, position*total)
+	}
+	return result
+}
+
+func mergePositions(a, b []float32, samples int, closed bool) []float32 {
+	positions := append(append([]float32{}, a...), b...)
+	for i := 0; i < samples; i++ {
+		positions = append(positions, float32(i)/float32(samples))
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		return positions[i] < positions[j]
+	})
+	merged := positions[:0]
+	for _, position := range positions {
+		if closed && position > 1-1e-6 {
+			break
+		}
+		if len(merged) == 0 || position-merged[len(merged)-1] > 1e-6 {
+			merged = append(merged, position)
+		}
+	}
+	return merged
+}
+
+func alignLoops(source, target []mgl32.Vec2, count int) float32 {
+	positions := make([]float32, count)
+	for i := range positions {
+		positions[i] = float32(i) / float32(count)
+	}
+	a, b := samplePositions(source, positions), samplePositions(target, positions)
+	best, bestError := 0, float32(math.Inf(1))
+	for shift := range b {
+		distance := float32(0)
+		for i, point := range a {
+			distance += point.Sub(b[(i+shift)%len(b)]).LenSqr()
+			if distance >= bestError {
+				break
+			}
+		}
+		if distance < bestError {
+			best, bestError = shift, distance
+		}
+	}
+	return float32(best) / float32(count)
+}
+
+func rotateLoop(points []mgl32.Vec2, position float32) []mgl32.Vec2 {
+	lengths := arcLengths(points)
+	distance := position * lengths[len(lengths)-1]
+	if distance <= 0 {
+		return points
+	}
+	start := pointAtLength(points, lengths, distance)
+	rotated := []mgl32.Vec2{start}
+	for i := 1; i < len(points); i++ {
+		if lengths[i] > distance {
+			rotated = append(rotated, points[i])
+		}
+	}
+	for i := 1; i < len(points); i++ {
+		if lengths[i] < distance {
+			rotated = append(rotated, points[i])
+		}
+	}
+	return append(rotated, start)
+}
+
+func (morph PathMorph) At(t float32) Path {
+	path := Path{}
+	for i, source := range morph.from {
+		target := morph.to[i]
+		for j, point := range source.Points {
+			position := point.Add(target.Points[j].Sub(point).Mul(t))
+			if j == 0 {
+				path.MoveTo(position)
+			} else {
+				path.LineTo(position)
+			}
+		}
+		if source.Closed {
+			path.ClosePath()
+		}
+	}
+	return path
+}
+
+func NewPathAnimation(buffer *PathBuffer, morph PathMorph, duration float32) *PathAnimation {
+	animation := &PathAnimation{buffer: buffer, morph: morph, duration: duration}
+	animation.apply()
+	return animation
+}
+
+func (animation *PathAnimation) SetEasing(easing func(t float32) float32) {
+	animation.easing = easing
+	animation.apply()
+}
+
+func (animation *PathAnimation) Progress() float32 {
+	if animation.duration <= 0 {
+		return 1
+	}
+	return min32(animation.elapsed/animation.duration, 1)
+}
+
+func (animation *PathAnimation) Update(dt float32) {
+	if animation.Done() {
+		return
+	}
+	animation.elapsed += dt
+	animation.apply()
+}
+
+func (animation *PathAnimation) apply() {
+	t := animation.Progress()
+	if animation.easing != nil {
+		t = animation.easing(t)
+	}
+	path := animation.morph.At(t)
+	animation.buffer.Update(&path)
+}
+
+func (animation *PathAnimation) Done() bool {
+	return animation.Progress() >= 1
+}
+
+func (animation *PathAnimation) Restart() {
+	animation.elapsed = 0
+	animation.apply()
+}
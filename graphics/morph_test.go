@@ -0,0 +1,77 @@
+package graphics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func containsVertices(t *testing.T, name string, polyline Polyline, vertices []mgl32.Vec2) {
+	for _, vertex := range vertices {
+		found := false
+		for _, point := range polyline.Points {
+			if point.ApproxEqualThreshold(vertex, 1e-3) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("%s: vertex %v is missing", name, vertex)
+		}
+	}
+}
+
+func TestPathMorphReproducesInputs(t *testing.T) {
+	rect := Rect(mgl32.Vec2{0, 0}, mgl32.Vec2{10, 10})
+	circle := Circle(mgl32.Vec2{5, 5}, 5)
+	tests := []struct {
+		name     string
+		from, to Path
+		samples  int
+	}{
+		{"rect to circle", rect, circle, 0},
+		{"circle to rect", circle, rect, 0},
+		{"rect to circle with samples", rect, circle, 100},
+	}
+	for _, test := range tests {
+		morph := CreatePathMorph(&test.from, &test.to, test.samples)
+		for _, end := range []struct {
+			t    float32
+			path Path
+		}{{0, test.from}, {1, test.to}} {
+			input := end.path.Flatten(end.path.flatTolerance())[0]
+			frame := morph.At(end.t)
+			output := frame.Flatten(frame.flatTolerance())
+			if len(output) != 1 {
+				t.Fatalf("%s at %v: got %d polylines, want 1", test.name, end.t, len(output))
+			}
+			containsVertices(t, test.name, output[0], input.Points)
+			if a, b := polylineArea(output[0].Points), polylineArea(input.Points); math.Abs(float64(a-b)) > 1e-3 {
+				t.Errorf("%s at %v: area %v, want %v", test.name, end.t, a, b)
+			}
+			for _, point := range output[0].Points {
+				if distance := distanceToPolyline(point, loopPoints(input, true)); distance > 1e-3 {
+					t.Errorf("%s at %v: point %v is %v off the input", test.name, end.t, point, distance)
+				}
+			}
+		}
+	}
+}
+
+func TestPathMorphExtraPolylines(t *testing.T) {
+	from := Rect(mgl32.Vec2{0, 0}, mgl32.Vec2{10, 10})
+	to := Rect(mgl32.Vec2{0, 0}, mgl32.Vec2{10, 10})
+	hole := Rect(mgl32.Vec2{2, 2}, mgl32.Vec2{2, 2})
+	to.segments = append(to.segments, hole.segments...)
+
+	morph := CreatePathMorph(&from, &to, 0)
+	start := morph.At(0)
+	if polylines := start.Flatten(1); len(polylines) != 1 {
+		t.Errorf("collapsed polyline should be empty at the start, got %d polylines", len(polylines))
+	}
+	end := morph.At(1)
+	if polylines := end.Flatten(1); len(polylines) != 2 {
+		t.Errorf("got %d polylines at the end, want 2", len(polylines))
+	}
+}
@@ -0,0 +1,208 @@
+package graphics
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+	"strings"
+	"unsafe"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+
+	_ "embed"
+)
+
+//go:embed shaders/paint.glsl
+var PaintGLSL string
+
+//go:embed shaders/stencil.fs
+var StencilFS string
+
+const (
+	paintSolid = iota
+	paintLinear
+	paintRadial
+	paintPattern
+)
+
+const gradientRampSize = 256
+
+type GradientStop struct {
+	Offset float32
+	Color  mgl32.Vec4
+}
+
+const maxGradientRamps = 32
+
+type Paint struct {
+	mode      int
+	color     mgl32.Vec4
+	transform mgl32.Mat3
+	stops     []GradientStop
+	texture   Texture
+}
+
+type rampCache struct {
+	entries map[string]*rampEntry
+	clock   int
+}
+
+type rampEntry struct {
+	key     string
+	texture Texture
+	used    int
+}
+
+func withPaint(source string) string {
+	return strings.Replace(source, "#pragma paint", PaintGLSL, 1)
+}
+
+func SolidPaint(color mgl32.Vec4) Paint {
+	return Paint{mode: paintSolid, color: color, transform: mgl32.Ident3()}
+}
+
+func LinearGradient(start, end mgl32.Vec2, stops ...GradientStop) Paint {
+	direction := end.Sub(start)
+	lengthSquared := direction.Dot(direction)
+	if lengthSquared == 0 {
+		lengthSquared = 1
+	}
+	direction = direction.Mul(1 / lengthSquared)
+	transform := mgl32.Mat3FromRows(
+		mgl32.Vec3{direction.X(), direction.Y(), -direction.Dot(start)},
+		mgl32.Vec3{-direction.Y(), direction.X(), 0},
+		mgl32.Vec3{0, 0, 1},
+	)
+	return Paint{mode: paintLinear, color: mgl32.Vec4{1, 1, 1, 1}, transform: transform, stops: sortStops(stops)}
+}
+
+func RadialGradient(center mgl32.Vec2, radius float32, stops ...GradientStop) Paint {
+	if radius <= 0 {
+		radius = 1
+	}
+	transform := mgl32.Scale2D(1/radius, 1/radius).Mul3(mgl32.Translate2D(-center.X(), -center.Y()))
+	return Paint{mode: paintRadial, color: mgl32.Vec4{1, 1, 1, 1}, transform: transform, stops: sortStops(stops)}
+}
+
+func PatternPaint(texture Texture, transform mgl32.Mat3) Paint {
+	return Paint{mode: paintPattern, color: mgl32.Vec4{1, 1, 1, 1}, transform: transform.Inv(), texture: texture}
+}
+
+func (paint Paint) WithTransform(transform mgl32.Mat3) Paint {
+	paint.transform = paint.transform.Mul3(transform.Inv())
+	return paint
+}
+
+func (paint Paint) WithTint(color mgl32.Vec4) Paint {
+	paint.color = color
+	return paint
+}
+
+func sortStops(stops []GradientStop) []GradientStop {
+	sorted := append([]GradientStop{}, stops...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Offset < sorted[j].Offset
+	})
+	return sorted
+}
+
+func gradientColorAt(stops []GradientStop, t float32) mgl32.Vec4 {
+	if len(stops) == 0 {
+		return mgl32.Vec4{0, 0, 0, 0}
+	}
+	if t <= stops[0].Offset {
+		return stops[0].Color
+	}
+	for i := 1; i < len(stops); i++ {
+		if t <= stops[i].Offset {
+			a, b := stops[i-1], stops[i]
+			span := b.Offset - a.Offset
+			if span <= 0 {
+				return b.Color
+			}
+			return a.Color.Add(b.Color.Sub(a.Color).Mul((t - a.Offset) / span))
+		}
+	}
+	return stops[len(stops)-1].Color
+}
+
+func gradientImage(stops []GradientStop) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, gradientRampSize, 1))
+	for x := 0; x < gradientRampSize; x++ {
+		c := gradientColorAt(stops, float32(x)/(gradientRampSize-1))
+		img.SetNRGBA(x, 0, color.NRGBA{
+			uint8(mgl32.Clamp(c.X(), 0, 1)*255 + 0.5),
+			uint8(mgl32.Clamp(c.Y(), 0, 1)*255 + 0.5),
+			uint8(mgl32.Clamp(c.Z(), 0, 1)*255 + 0.5),
+			uint8(mgl32.Clamp(c.W(), 0, 1)*255 + 0.5),
+		})
+	}
+	return img
+}
+
+func stopsKey(stops []GradientStop) string {
+	key := make([]byte, 0, len(stops)*20)
+	for _, stop := range stops {
+		for _, value := range []float32{stop.Offset, stop.Color[0], stop.Color[1], stop.Color[2], stop.Color[3]} {
+			bits := math.Float32bits(value)
+			key = append(key, byte(bits), byte(bits>>8), byte(bits>>16), byte(bits>>24))
+		}
+	}
+	return string(key)
+}
+
+func newRampCache() *rampCache {
+	return &rampCache{entries: make(map[string]*rampEntry)}
+}
+
+func (cache *rampCache) texture(stops []GradientStop) Texture {
+	key := stopsKey(stops)
+	cache.clock++
+	if entry, ok := cache.entries[key]; ok {
+		entry.used = cache.clock
+		return entry.texture
+	}
+
+	img := gradientImage(stops)
+	var entry *rampEntry
+	if len(cache.entries) >= maxGradientRamps {
+		for _, candidate := range cache.entries {
+			if entry == nil || candidate.used < entry.used {
+				entry = candidate
+			}
+		}
+		delete(cache.entries, entry.key)
+		gl.BindTexture(gl.TEXTURE_2D, entry.texture.textureID)
+		gl.TexSubImage2D(gl.TEXTURE_2D, 0, 0, 0, gradientRampSize, 1, gl.RGBA, gl.UNSIGNED_BYTE, unsafe.Pointer(&img.Pix[0]))
+	} else {
+		entry = &rampEntry{texture: TextureFromRGBA(img)}
+	}
+	entry.key = key
+	entry.used = cache.clock
+	cache.entries[key] = entry
+	return entry.texture
+}
+
+func (cache *rampCache) Delete() {
+	for key, entry := range cache.entries {
+		entry.texture.Delete()
+		delete(cache.entries, key)
+	}
+}
+
+func (paint Paint) bind(uniforms map[string]Uniform, ramps *rampCache) map[string]Uniform {
+	uniforms["color"] = paint.color
+	uniforms["paintMode"] = paint.mode
+	uniforms["paintTransform"] = paint.transform
+	uniforms["paintSampler"] = 0
+
+	switch paint.mode {
+	case paintLinear, paintRadial:
+		ramps.texture(paint.stops).Bind(0)
+	case paintPattern:
+		paint.texture.Bind(0)
+	}
+	return uniforms
+}
@@ -0,0 +1,59 @@
+package graphics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestGradientColorAt(t *testing.T) {
+	stops := sortStops([]GradientStop{
+		{1, mgl32.Vec4{0, 0, 1, 1}},
+		{0, mgl32.Vec4{1, 0, 0, 1}},
+		{0.5, mgl32.Vec4{0, 1, 0, 1}},
+	})
+	tests := []struct {
+		t     float32
+		color mgl32.Vec4
+	}{
+		{-1, mgl32.Vec4{1, 0, 0, 1}},
+		{0.25, mgl32.Vec4{0.5, 0.5, 0, 1}},
+		{0.5, mgl32.Vec4{0, 1, 0, 1}},
+		{2, mgl32.Vec4{0, 0, 1, 1}},
+	}
+	for _, test := range tests {
+		if color := gradientColorAt(stops, test.t); !color.ApproxEqual(test.color) {
+			t.Errorf("t %v: got %v, want %v", test.t, color, test.color)
+		}
+	}
+}
+
+func TestStopsKey(t *testing.T) {
+	a := []GradientStop{{0, mgl32.Vec4{1, 0, 0, 1}}, {1, mgl32.Vec4{0, 0, 1, 1}}}
+	b := []GradientStop{{0, mgl32.Vec4{1, 0, 0, 1}}, {1, mgl32.Vec4{0, 0, 1, 1}}}
+	c := []GradientStop{{0, mgl32.Vec4{1, 0, 0, 1}}, {1, mgl32.Vec4{0, 0, 1, 0.5}}}
+	if stopsKey(a) != stopsKey(b) {
+		t.Error("equal stop lists should share a ramp")
+	}
+	if stopsKey(a) == stopsKey(c) {
+		t.Error("different stop lists should not share a ramp")
+	}
+}
+
+func TestLinearGradientTransform(t *testing.T) {
+	paint := LinearGradient(mgl32.Vec2{10, 0}, mgl32.Vec2{10, 20})
+	for _, test := range []struct {
+		point mgl32.Vec2
+		t     float32
+	}{
+		{mgl32.Vec2{10, 0}, 0},
+		{mgl32.Vec2{50, 10}, 0.5},
+		{mgl32.Vec2{0, 20}, 1},
+	} {
+		position := paint.transform.Mul3x1(test.point.Vec3(1)).X()
+		if math.Abs(float64(position-test.t)) > 1e-5 {
+			t.Errorf("%v: gradient position %v, want %v", test.point, position, test.t)
+		}
+	}
+}
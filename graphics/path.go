@@ -2,6 +2,7 @@ package graphics
 
 import (
 	"log"
+	"math"
 	"unsafe"
 
 	"github.com/go-gl/gl/v4.1-core/gl"
@@ -19,8 +20,8 @@ var StrokeFS string
 //go:embed shaders/fill.fs
 var FillFS string
 
-//go:embed shaders/stroke.gs
-var StrokeGS string
+//go:embed shaders/stroke.vs
+var StrokeVS string
 
 //go:embed shaders/fill.gs
 var FillGS string
@@ -28,12 +29,51 @@ var FillGS string
 type PathRenderer struct {
 	strokeProgram Program
 	fillProgram   Program
+	coverProgram  Program
+	fringeProgram Program
+	stencilFill   bool
+	coverVAO      uint32
+	coverVBO      Buffer
+	ramps         *rampCache
+	viewport      mgl32.Vec2
 }
 
+type FillRule int
+
+const (
+	FillNonZero FillRule = iota
+	FillEvenOdd
+)
+
+const DefaultPathTolerance = 0.25
+
 type Path struct {
-	points     []PathVertex
+	segments   []pathSegment
 	lastPoint  mgl32.Vec2
-	lastNormal mgl32.Vec2
+	startPoint mgl32.Vec2
+	tolerance  float32
+}
+
+type pathSegmentKind int
+
+const (
+	segmentMove pathSegmentKind = iota
+	segmentLine
+	segmentQuadratic
+	segmentCubic
+	segmentArc
+	segmentClose
+)
+
+type pathSegment struct {
+	kind   pathSegmentKind
+	points [4]mgl32.Vec2
+	arc    pathArc
+}
+
+type pathArc struct {
+	center, radius                   mgl32.Vec2
+	rotation, startAngle, deltaAngle float64
 }
 
 type PathVertex struct {
@@ -42,95 +82,262 @@ type PathVertex struct {
 }
 
 type PathBuffer struct {
-	vbo  Buffer
-	vao  uint32
-	size int
+	vbo       Buffer
+	vao       uint32
+	size      int
+	polylines []Polyline
+	min, max  mgl32.Vec2
+	tolerance float32
+	strokes   map[strokeKey]*strokeMesh
+	fringe    *fringeMesh
+	fills     map[FillRule]*tessMesh
+	capacity  int
+	revision  int
 }
 
-func perp(vec mgl32.Vec2) mgl32.Vec2 {
-	return mgl32.Vec2{vec.Y(), -vec.X()}
+func (path *Path) SetTolerance(tolerance float32) {
+	path.tolerance = tolerance
+}
+
+func (path *Path) MoveTo(newPoint mgl32.Vec2) {
+	path.segments = append(path.segments, pathSegment{kind: segmentMove, points: [4]mgl32.Vec2{newPoint}})
+	path.lastPoint = newPoint
+	path.startPoint = newPoint
 }
 
 func (path *Path) LineTo(newPoint mgl32.Vec2) {
-	newNormal := perp(newPoint.Sub(path.lastPoint)).Normalize()
-	if !path.lastNormal.ApproxEqual(mgl32.Vec2{0, 0}) {
-		averageNormal := averageNormals(path.lastNormal, newNormal)
-		path.points = append(path.points, PathVertex{path.lastPoint, averageNormal}, PathVertex{path.lastPoint, averageNormal})
-	} else {
-		path.points = append(path.points, PathVertex{path.lastPoint, newNormal})
+	if newPoint.ApproxEqual(path.lastPoint) {
+		return
 	}
+	path.segments = append(path.segments, pathSegment{kind: segmentLine, points: [4]mgl32.Vec2{path.lastPoint, newPoint}})
 	path.lastPoint = newPoint
-	path.lastNormal = newNormal
 }
 
-func normalInQuadratic(start, control, end mgl32.Vec2, t float32) mgl32.Vec2 {
-	tangent := control.Sub(start).Mul(2 * (1 - t)).Add(end.Sub(control).Mul(2 * t))
-	return perp(tangent).Normalize()
+func (path *Path) QuadraticTo(controlPoint, newPoint mgl32.Vec2) {
+	start := path.lastPoint
+	if start.ApproxEqual(newPoint) && start.ApproxEqual(controlPoint) {
+		return
+	}
+	path.segments = append(path.segments, pathSegment{kind: segmentQuadratic, points: [4]mgl32.Vec2{start, controlPoint, newPoint}})
+	path.lastPoint = newPoint
 }
 
-func pointInQuadratic(start, control, end mgl32.Vec2, t float32) mgl32.Vec2 {
-	return start.Mul((1 - t) * (1 - t)).Add(control.Mul(2 * (1 - t) * t)).Add(end.Mul(t * t))
+func (path *Path) CubicTo(controlPoint1, controlPoint2, newPoint mgl32.Vec2) {
+	start := path.lastPoint
+	if start.ApproxEqual(newPoint) && start.ApproxEqual(controlPoint1) && start.ApproxEqual(controlPoint2) {
+		return
+	}
+	path.segments = append(path.segments, pathSegment{kind: segmentCubic, points: [4]mgl32.Vec2{start, controlPoint1, controlPoint2, newPoint}})
+	path.lastPoint = newPoint
 }
 
-func (path *Path) QuadraticTo(controlPoint, newPoint mgl32.Vec2) {
+func vectorAngle(ux, uy, vx, vy float64) float64 {
+	return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+}
 
-	normal := normalInQuadratic(path.lastPoint, controlPoint, newPoint, 0.0)
-	if !path.lastNormal.ApproxEqual(mgl32.Vec2{0, 0}) {
-		averageNormal := averageNormals(path.lastNormal, normal)
-		path.points = append(path.points, PathVertex{path.lastPoint, averageNormal}, PathVertex{path.lastPoint, averageNormal})
-	} else {
-		path.points = append(path.points, PathVertex{path.lastPoint, normal})
+func (path *Path) ArcTo(radius mgl32.Vec2, rotation float32, largeArc, sweep bool, newPoint mgl32.Vec2) {
+	start := path.lastPoint
+	if start.ApproxEqual(newPoint) {
+		return
+	}
+	rx, ry := math.Abs(float64(radius.X())), math.Abs(float64(radius.Y()))
+	if rx == 0 || ry == 0 {
+		path.LineTo(newPoint)
+		return
 	}
 
-	n := 4
+	phi := float64(rotation)
+	sinPhi, cosPhi := math.Sincos(phi)
+	dx := float64(start.X()-newPoint.X()) / 2
+	dy := float64(start.Y()-newPoint.Y()) / 2
+	x1 := cosPhi*dx + sinPhi*dy
+	y1 := -sinPhi*dx + cosPhi*dy
+
+	lambda := x1*x1/(rx*rx) + y1*y1/(ry*ry)
+	if lambda > 1 {
+		rx *= math.Sqrt(lambda)
+		ry *= math.Sqrt(lambda)
+	}
 
-	for i := 1; i < n; i++ {
-		point := pointInQuadratic(path.lastPoint, controlPoint, newPoint, float32(i)/float32(n))
-		normal := normalInQuadratic(path.lastPoint, controlPoint, newPoint, float32(i)/float32(n))
-		path.points = append(path.points, PathVertex{point, normal}, PathVertex{point, normal})
+	numerator := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	denominator := rx*rx*y1*y1 + ry*ry*x1*x1
+	coefficient := math.Sqrt(math.Max(0, numerator/denominator))
+	if largeArc == sweep {
+		coefficient = -coefficient
 	}
+	cx1 := coefficient * rx * y1 / ry
+	cy1 := -coefficient * ry * x1 / rx
 
+	center := mgl32.Vec2{
+		float32(cosPhi*cx1 - sinPhi*cy1 + float64(start.X()+newPoint.X())/2),
+		float32(sinPhi*cx1 + cosPhi*cy1 + float64(start.Y()+newPoint.Y())/2),
+	}
+	startAngle := vectorAngle(1, 0, (x1-cx1)/rx, (y1-cy1)/ry)
+	deltaAngle := vectorAngle((x1-cx1)/rx, (y1-cy1)/ry, (-x1-cx1)/rx, (-y1-cy1)/ry)
+	if !sweep && deltaAngle > 0 {
+		deltaAngle -= 2 * math.Pi
+	} else if sweep && deltaAngle < 0 {
+		deltaAngle += 2 * math.Pi
+	}
+
+	path.segments = append(path.segments, pathSegment{
+		kind:   segmentArc,
+		points: [4]mgl32.Vec2{start, newPoint},
+		arc:    pathArc{center, mgl32.Vec2{float32(rx), float32(ry)}, phi, startAngle, deltaAngle},
+	})
 	path.lastPoint = newPoint
-	path.lastNormal = normalInQuadratic(path.lastPoint, controlPoint, newPoint, 1.0)
 }
 
-func averageNormals(n1, n2 mgl32.Vec2) mgl32.Vec2 {
-	normal := n1.Add(n2).Normalize()
-	factor := 1.0 / normal.Dot(n1)
-	return normal.Mul(factor)
+func (path *Path) ClosePath() {
+	if len(path.segments) == 0 || path.segments[len(path.segments)-1].kind == segmentMove || path.segments[len(path.segments)-1].kind == segmentClose {
+		return
+	}
+	path.segments = append(path.segments, pathSegment{kind: segmentClose, points: [4]mgl32.Vec2{path.lastPoint, path.startPoint}})
+	path.lastPoint = path.startPoint
 }
 
-func normalInArc(center, point mgl32.Vec2) mgl32.Vec2 {
-	return point.Sub(center).Normalize()
+func (path *Path) Transform(transform mgl32.Mat3) Path {
+	apply := func(point mgl32.Vec2) mgl32.Vec2 {
+		return transform.Mul3x1(point.Vec3(1)).Vec2()
+	}
+
+	result := Path{tolerance: path.tolerance}
+	for _, segment := range path.segments {
+		if segment.kind == segmentArc {
+			for _, cubic := range arcToCubics(segment.arc) {
+				result.segments = append(result.segments, pathSegment{kind: segmentCubic, points: [4]mgl32.Vec2{apply(cubic[0]), apply(cubic[1]), apply(cubic[2]), apply(cubic[3])}})
+			}
+			continue
+		}
+		for i := range segment.points {
+			segment.points[i] = apply(segment.points[i])
+		}
+		result.segments = append(result.segments, segment)
+	}
+	result.lastPoint = apply(path.lastPoint)
+	result.startPoint = apply(path.startPoint)
+	return result
 }
 
-func (path *Path) MoveTo(newPoint mgl32.Vec2) {
-	if !path.lastNormal.ApproxEqual(mgl32.Vec2{0, 0}) {
-		path.points = append(path.points, PathVertex{path.lastPoint, path.lastNormal})
+func arcToCubics(arc pathArc) [][4]mgl32.Vec2 {
+	n := int(math.Ceil(math.Abs(arc.deltaAngle) / (math.Pi / 2)))
+	if n < 1 {
+		n = 1
 	}
-	path.lastPoint = newPoint
-	path.lastNormal = mgl32.Vec2{0, 0}
+	step := arc.deltaAngle / float64(n)
+	k := float32(4.0 / 3.0 * math.Tan(step/4))
+
+	derivative := func(angle float64) mgl32.Vec2 {
+		sin, cos := math.Sincos(angle)
+		sinRotation, cosRotation := math.Sincos(arc.rotation)
+		rx, ry := float64(arc.radius.X()), float64(arc.radius.Y())
+		return mgl32.Vec2{float32(-rx*sin*cosRotation - ry*cos*sinRotation), float32(-rx*sin*sinRotation + ry*cos*cosRotation)}
+	}
+
+	cubics := make([][4]mgl32.Vec2, n)
+	for i := range cubics {
+		a0 := arc.startAngle + step*float64(i)
+		a1 := a0 + step
+		p0 := pointInArc(arc.center, arc.radius, arc.rotation, a0)
+		p3 := pointInArc(arc.center, arc.radius, arc.rotation, a1)
+		cubics[i] = [4]mgl32.Vec2{p0, p0.Add(derivative(a0).Mul(k)), p3.Sub(derivative(a1).Mul(k)), p3}
+	}
+	return cubics
+}
+
+func (path *Path) Empty() bool {
+	for _, segment := range path.segments {
+		if segment.kind != segmentMove && segment.kind != segmentClose {
+			return false
+		}
+	}
+	return true
+}
+
+func (path *Path) flatTolerance() float32 {
+	if path.tolerance <= 0 {
+		return DefaultPathTolerance
+	}
+	return path.tolerance
 }
 
 func (path *Path) ToBuffer() PathBuffer {
-	points := path.points
-	if !path.lastNormal.ApproxEqual(mgl32.Vec2{0, 0}) {
-		points = append(path.points, PathVertex{path.lastPoint, path.lastNormal})
+	tolerance := path.flatTolerance()
+	return createPathBuffer(path.flatten(tolerance), tolerance)
+}
+
+func (path *Path) ToBufferWithTransform(transform mgl32.Mat3, tolerance float32) PathBuffer {
+	return createPathBuffer(path.flattenWithTransform(transform, tolerance))
+}
+
+func (path *Path) flattenWithTransform(transform mgl32.Mat3, tolerance float32) (*pathBuilder, float32) {
+	tolerance /= transformScale(transform)
+	return path.flatten(tolerance), tolerance
+}
+
+func transformScale(transform mgl32.Mat3) float32 {
+	scale := max32(transform.Col(0).Vec2().Len(), transform.Col(1).Vec2().Len())
+	if scale == 0 {
+		return 1
 	}
+	return scale
+}
 
-	vao := uint32(0)
-	gl.CreateVertexArrays(1, &vao)
-	gl.BindVertexArray(vao)
+func createPathBuffer(builder *pathBuilder, tolerance float32) PathBuffer {
+	buffer := PathBuffer{
+		tolerance: tolerance,
+		strokes:   make(map[strokeKey]*strokeMesh),
+		fringe:    &fringeMesh{},
+		fills:     make(map[FillRule]*tessMesh),
+	}
+	gl.CreateVertexArrays(1, &buffer.vao)
+	gl.BindVertexArray(buffer.vao)
 
-	vbo := CreateBuffer()
-	vbo.Bind()
-	gl.BufferData(gl.ARRAY_BUFFER, len(points)*int(unsafe.Sizeof(PathVertex{})), unsafe.Pointer(&points[0]), gl.STATIC_DRAW)
+	buffer.vbo = CreateBuffer()
+	buffer.vbo.Bind()
 	gl.EnableVertexAttribArray(0)
 	gl.VertexAttribPointerWithOffset(0, 2, gl.FLOAT, false, int32(unsafe.Sizeof(PathVertex{})), unsafe.Offsetof(PathVertex{}.pos))
 	gl.EnableVertexAttribArray(1)
 	gl.VertexAttribPointerWithOffset(1, 2, gl.FLOAT, false, int32(unsafe.Sizeof(PathVertex{})), unsafe.Offsetof(PathVertex{}.normal))
 
-	return PathBuffer{vbo, vao, len(points)}
+	buffer.upload(builder)
+	return buffer
+}
+
+func (buffer *PathBuffer) Update(path *Path) {
+	buffer.upload(path.flatten(buffer.tolerance))
+}
+
+func (buffer *PathBuffer) upload(builder *pathBuilder) {
+	points := builder.points
+	buffer.vbo.Bind()
+	if len(points) > buffer.capacity {
+		gl.BufferData(gl.ARRAY_BUFFER, len(points)*int(unsafe.Sizeof(PathVertex{})), unsafe.Pointer(&points[0]), gl.DYNAMIC_DRAW)
+		buffer.capacity = len(points)
+	} else if len(points) > 0 {
+		gl.BufferSubData(gl.ARRAY_BUFFER, 0, len(points)*int(unsafe.Sizeof(PathVertex{})), unsafe.Pointer(&points[0]))
+	}
+	buffer.size = len(points)
+	buffer.polylines = builder.polylines
+	buffer.min, buffer.max = polylineBounds(builder.polylines)
+	buffer.revision++
+}
+
+func polylineBounds(polylines []Polyline) (mgl32.Vec2, mgl32.Vec2) {
+	min, max := mgl32.Vec2{0, 0}, mgl32.Vec2{0, 0}
+	first := true
+	for _, polyline := range polylines {
+		for _, point := range polyline.Points {
+			if first {
+				min, max = point, point
+				first = false
+				continue
+			}
+			min = mgl32.Vec2{min32(min.X(), point.X()), min32(min.Y(), point.Y())}
+			max = mgl32.Vec2{max32(max.X(), point.X()), max32(max.Y(), point.Y())}
+		}
+	}
+	return min, max
 }
 
 func check(err error) {
@@ -140,69 +347,216 @@ func check(err error) {
 }
 
 func CreatePathRenderer() PathRenderer {
-	renderer := PathRenderer{}
+	renderer := PathRenderer{ramps: newRampCache()}
 	vs, err := CreateVertexShader(PathVS)
 	check(err)
-	fs, err := CreateFragmentShader(FillFS)
+	fs, err := CreateFragmentShader(StencilFS)
 	check(err)
-	gs, err := CreateGeometryShader(FillGS)
+	if gs, err := CreateGeometryShader(FillGS); err == nil {
+		renderer.fillProgram, err = CreateProgramVSGSFS(vs, gs, fs)
+		renderer.stencilFill = err == nil
+	}
+
+	fs, err = CreateFragmentShader(withPaint(FillFS))
 	check(err)
-	program, err := CreateProgramVSGSFS(vs, gs, fs)
+	program, err := CreateProgramVSFS(vs, fs)
 	check(err)
-	renderer.fillProgram = program
+	renderer.coverProgram = program
 
-	gs, err = CreateGeometryShader(StrokeGS)
+	vs, err = CreateVertexShader(FringeVS)
 	check(err)
-	fs, err = CreateFragmentShader(StrokeFS)
+	fs, err = CreateFragmentShader(withPaint(FringeFS))
 	check(err)
-	program, err = CreateProgramVSGSFS(vs, gs, fs)
+	program, err = CreateProgramVSFS(vs, fs)
+	check(err)
+	renderer.fringeProgram = program
+
+	gl.CreateVertexArrays(1, &renderer.coverVAO)
+	gl.BindVertexArray(renderer.coverVAO)
+	renderer.coverVBO = CreateBuffer()
+	renderer.coverVBO.Bind()
+	gl.BufferData(gl.ARRAY_BUFFER, 4*int(unsafe.Sizeof(PathVertex{})), nil, gl.DYNAMIC_DRAW)
+	gl.EnableVertexAttribArray(0)
+	gl.VertexAttribPointerWithOffset(0, 2, gl.FLOAT, false, int32(unsafe.Sizeof(PathVertex{})), unsafe.Offsetof(PathVertex{}.pos))
+	gl.EnableVertexAttribArray(1)
+	gl.VertexAttribPointerWithOffset(1, 2, gl.FLOAT, false, int32(unsafe.Sizeof(PathVertex{})), unsafe.Offsetof(PathVertex{}.normal))
+
+	vs, err = CreateVertexShader(StrokeVS)
+	check(err)
+	fs, err = CreateFragmentShader(withPaint(StrokeFS))
+	check(err)
+	program, err = CreateProgramVSFS(vs, fs)
 	check(err)
 	renderer.strokeProgram = program
 
+	viewport := [4]int32{}
+	gl.GetIntegerv(gl.VIEWPORT, &viewport[0])
+	renderer.SetViewport(int(viewport[2]), int(viewport[3]))
+
 	return renderer
 }
 
-func (renderer *PathRenderer) Fill(path PathBuffer, transform mgl32.Mat3, color mgl32.Vec4) {
+func (renderer *PathRenderer) SetViewport(width, height int) {
+	renderer.viewport = mgl32.Vec2{float32(width), float32(height)}
+}
+
+func (renderer *PathRenderer) Fill(path PathBuffer, transform mgl32.Mat3, paint Paint, style FillStyle) {
+	if path.size == 0 {
+		return
+	}
+
+	if style.Tessellate || !renderer.stencilFill {
+		renderer.fillTessellated(path, transform, paint, style)
+		return
+	}
+
 	gl.Enable(gl.STENCIL_TEST)
-	gl.Clear(gl.STENCIL_BUFFER_BIT)
-	gl.StencilOp(gl.INVERT, gl.INVERT, gl.INVERT)
-	gl.StencilFunc(gl.ALWAYS, 0, 1)
+	gl.StencilMask(0xFF)
+	gl.StencilFunc(gl.ALWAYS, 0, 0xFF)
+	if style.Rule == FillEvenOdd {
+		gl.StencilOp(gl.KEEP, gl.KEEP, gl.INVERT)
+	} else {
+		gl.StencilOpSeparate(gl.FRONT, gl.KEEP, gl.KEEP, gl.INCR_WRAP)
+		gl.StencilOpSeparate(gl.BACK, gl.KEEP, gl.KEEP, gl.DECR_WRAP)
+	}
 	gl.ColorMask(false, false, false, false)
 
 	gl.BindVertexArray(path.vao)
 	renderer.fillProgram.Bind(map[string]Uniform{
 		"transform": transform,
-		"color":     color,
-		"origin":    mgl32.Vec2{0, 0},
+		"origin":    path.min,
 	})
 	gl.DrawArrays(gl.LINES, 0, int32(path.size))
 
 	gl.ColorMask(true, true, true, true)
+	if style.Antialias {
+		gl.StencilFunc(gl.EQUAL, 0, 0xFF)
+		gl.StencilOp(gl.KEEP, gl.KEEP, gl.KEEP)
+		renderer.drawFringe(path, transform, paint, style.FringeWidth)
+	}
+
+	gl.StencilFunc(gl.NOTEQUAL, 0, 0xFF)
+	gl.StencilOp(gl.ZERO, gl.ZERO, gl.ZERO)
+	renderer.cover(path.min, path.max, transform, paint)
+
+	gl.Disable(gl.STENCIL_TEST)
+}
+
+func (renderer *PathRenderer) fillTessellated(path PathBuffer, transform mgl32.Mat3, paint Paint, style FillStyle) {
+	mesh := path.fillMesh(style.Rule)
+	if mesh.size == 0 {
+		return
+	}
+	uniforms := paint.bind(map[string]Uniform{
+		"transform": transform,
+	}, renderer.ramps)
+	if !style.Antialias {
+		renderer.coverProgram.Bind(uniforms)
+		mesh.draw()
+		return
+	}
+
+	gl.Enable(gl.STENCIL_TEST)
+	gl.StencilMask(0xFF)
+	gl.StencilFunc(gl.ALWAYS, 1, 0xFF)
+	gl.StencilOp(gl.KEEP, gl.KEEP, gl.REPLACE)
+	renderer.coverProgram.Bind(uniforms)
+	mesh.draw()
+
+	gl.StencilFunc(gl.EQUAL, 0, 0xFF)
 	gl.StencilOp(gl.KEEP, gl.KEEP, gl.KEEP)
-	gl.StencilFunc(gl.EQUAL, 0xFF, 0xFF)
-	gl.DrawArrays(gl.LINES, 0, int32(path.size))
+	renderer.drawFringe(path, transform, paint, style.FringeWidth)
+
+	gl.ColorMask(false, false, false, false)
+	gl.StencilFunc(gl.ALWAYS, 0, 0xFF)
+	gl.StencilOp(gl.ZERO, gl.ZERO, gl.ZERO)
+	renderer.coverProgram.Bind(uniforms)
+	mesh.draw()
+	gl.ColorMask(true, true, true, true)
 
 	gl.Disable(gl.STENCIL_TEST)
 }
 
-func (renderer *PathRenderer) Stroke(path PathBuffer, transform mgl32.Mat3, color mgl32.Vec4, width float32) {
-	gl.BindVertexArray(path.vao)
-	renderer.strokeProgram.Bind(map[string]Uniform{
+func (renderer *PathRenderer) cover(min, max mgl32.Vec2, transform mgl32.Mat3, paint Paint) {
+	quad := []PathVertex{
+		{pos: min},
+		{pos: mgl32.Vec2{max.X(), min.Y()}},
+		{pos: mgl32.Vec2{min.X(), max.Y()}},
+		{pos: max},
+	}
+	gl.BindVertexArray(renderer.coverVAO)
+	renderer.coverVBO.Bind()
+	gl.BufferSubData(gl.ARRAY_BUFFER, 0, len(quad)*int(unsafe.Sizeof(PathVertex{})), unsafe.Pointer(&quad[0]))
+	renderer.coverProgram.Bind(paint.bind(map[string]Uniform{
 		"transform": transform,
-		"color":     color,
-		"width":     width,
-		"sides":     0,
-		"threshold": float32(0.004),
-	})
-	gl.DrawArrays(gl.LINES, 0, int32(path.size))
+	}, renderer.ramps))
+	gl.DrawArrays(gl.TRIANGLE_STRIP, 0, 4)
+}
+
+func (renderer *PathRenderer) Stroke(path PathBuffer, transform mgl32.Mat3, paint Paint, style StrokeStyle) {
+	mesh := path.stroke(style)
+	if mesh.size == 0 {
+		return
+	}
+	dashes := make([]float32, maxShaderDashes)
+	count := 0
+	if !style.key().cpuDashes {
+		count = copy(dashes, shaderDashes(style.Dashes))
+	}
+	uniforms := paint.bind(map[string]Uniform{
+		"transform":  transform,
+		"dashes":     dashes,
+		"dashCount":  count,
+		"dashOffset": style.DashOffset,
+		"dashCap":    int(style.Cap),
+		"halfWidth":  style.Width / 2,
+	}, renderer.ramps)
+	gl.BindVertexArray(mesh.vao)
+
+	gl.Enable(gl.STENCIL_TEST)
+	gl.StencilMask(0xFF)
+	gl.StencilFunc(gl.EQUAL, 0, 0xFF)
+	gl.StencilOp(gl.KEEP, gl.KEEP, gl.INCR)
+	uniforms["threshold"] = float32(1 - 0.5/255)
+	renderer.strokeProgram.Bind(uniforms)
+	gl.DrawArrays(gl.TRIANGLES, 0, int32(mesh.size))
+
+	gl.StencilOp(gl.KEEP, gl.KEEP, gl.KEEP)
+	uniforms["threshold"] = float32(0)
+	renderer.strokeProgram.Bind(uniforms)
+	gl.DrawArrays(gl.TRIANGLES, 0, int32(mesh.size))
+
+	gl.ColorMask(false, false, false, false)
+	gl.StencilFunc(gl.ALWAYS, 0, 0xFF)
+	gl.StencilOp(gl.ZERO, gl.ZERO, gl.ZERO)
+	gl.DrawArrays(gl.TRIANGLES, 0, int32(mesh.size))
+	gl.ColorMask(true, true, true, true)
+
+	gl.Disable(gl.STENCIL_TEST)
 }
 
 func (renderer PathRenderer) Delete() {
-	renderer.fillProgram.Delete()
+	if renderer.stencilFill {
+		renderer.fillProgram.Delete()
+	}
 	renderer.strokeProgram.Delete()
+	renderer.coverProgram.Delete()
+	renderer.fringeProgram.Delete()
+	gl.DeleteVertexArrays(1, &renderer.coverVAO)
+	renderer.coverVBO.Delete()
+	renderer.ramps.Delete()
 }
 
 func (buffer PathBuffer) Delete() {
 	gl.DeleteVertexArrays(1, &buffer.vao)
 	buffer.vbo.Delete()
+	for key, mesh := range buffer.strokes {
+		mesh.Delete()
+		delete(buffer.strokes, key)
+	}
+	buffer.fringe.Delete()
+	for rule, mesh := range buffer.fills {
+		mesh.Delete()
+		delete(buffer.fills, rule)
+	}
 }
@@ -0,0 +1,103 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func polylineEdges(polyline Polyline, closed bool, visit func(a, b mgl32.Vec2) bool) {
+	points := polyline.Points
+	for i := 0; i+1 < len(points); i++ {
+		if !visit(points[i], points[i+1]) {
+			return
+		}
+	}
+	if closed && len(points) > 2 && !points[0].ApproxEqual(points[len(points)-1]) {
+		visit(points[len(points)-1], points[0])
+	}
+}
+
+// Bounds returns zero vectors for an empty path.
+func (path *Path) Bounds() (mgl32.Vec2, mgl32.Vec2) {
+	return polylineBounds(path.Flatten(path.flatTolerance()))
+}
+
+func (path *Path) Length() float32 {
+	length := float32(0)
+	for _, polyline := range path.Flatten(path.flatTolerance()) {
+		polylineEdges(polyline, polyline.Closed, func(a, b mgl32.Vec2) bool {
+			length += b.Sub(a).Len()
+			return true
+		})
+	}
+	return length
+}
+
+func (path *Path) PointAt(distance float32) (mgl32.Vec2, mgl32.Vec2) {
+	point, tangent := mgl32.Vec2{}, mgl32.Vec2{1, 0}
+	found := false
+	for _, polyline := range path.Flatten(path.flatTolerance()) {
+		polylineEdges(polyline, polyline.Closed, func(a, b mgl32.Vec2) bool {
+			edge := b.Sub(a)
+			length := edge.Len()
+			if length == 0 {
+				return true
+			}
+			tangent = edge.Mul(1 / length)
+			if distance <= length {
+				point = a.Add(tangent.Mul(max32(distance, 0)))
+				found = true
+				return false
+			}
+			distance -= length
+			point = b
+			return true
+		})
+		if found {
+			break
+		}
+	}
+	return point, tangent
+}
+
+func (path *Path) Contains(point mgl32.Vec2, rule FillRule) bool {
+	winding := 0
+	for _, polyline := range path.Flatten(path.flatTolerance()) {
+		polylineEdges(polyline, true, func(a, b mgl32.Vec2) bool {
+			if a.Y() <= point.Y() {
+				if b.Y() > point.Y() && cross2(a, b, point) > 0 {
+					winding++
+				}
+			} else if b.Y() <= point.Y() && cross2(a, b, point) < 0 {
+				winding--
+			}
+			return true
+		})
+	}
+	if rule == FillEvenOdd {
+		return winding%2 != 0
+	}
+	return winding != 0
+}
+
+// ClosestPoint returns the query point and +Inf for a path without edges.
+func (path *Path) ClosestPoint(point mgl32.Vec2) (mgl32.Vec2, float32) {
+	closest := point
+	best := float32(math.Inf(1))
+	for _, polyline := range path.Flatten(path.flatTolerance()) {
+		polylineEdges(polyline, polyline.Closed, func(a, b mgl32.Vec2) bool {
+			edge := b.Sub(a)
+			t := float32(0)
+			if length := edge.Dot(edge); length > 0 {
+				t = min32(max32(point.Sub(a).Dot(edge)/length, 0), 1)
+			}
+			candidate := a.Add(edge.Mul(t))
+			if distance := candidate.Sub(point).Len(); distance < best {
+				closest, best = candidate, distance
+			}
+			return true
+		})
+	}
+	return closest, best
+}
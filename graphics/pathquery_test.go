@@ -0,0 +1,115 @@
+package graphics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestPathBoundsAndLength(t *testing.T) {
+	rect := Rect(mgl32.Vec2{10, 20}, mgl32.Vec2{30, 40})
+	if min, max := rect.Bounds(); min != (mgl32.Vec2{10, 20}) || max != (mgl32.Vec2{40, 60}) {
+		t.Errorf("got rect bounds %v %v, want [10 20] [40 60]", min, max)
+	}
+	empty := Path{}
+	if min, max := empty.Bounds(); min != (mgl32.Vec2{}) || max != (mgl32.Vec2{}) {
+		t.Errorf("got empty bounds %v %v, want zero vectors", min, max)
+	}
+
+	open := PolylinePath([]mgl32.Vec2{{0, 0}, {3, 4}, {3, 10}}, false)
+	circle := Circle(mgl32.Vec2{0, 0}, 10)
+	tests := []struct {
+		name   string
+		path   Path
+		length float64
+	}{
+		{"rect", rect, 140},
+		{"open polyline", open, 11},
+		{"circle", circle, 20 * math.Pi},
+		{"empty", empty, 0},
+	}
+	for _, test := range tests {
+		if length := test.path.Length(); math.Abs(float64(length)-test.length) > test.length*0.01+1e-4 {
+			t.Errorf("%s: got length %v, want %v", test.name, length, test.length)
+		}
+	}
+}
+
+func TestPathPointAt(t *testing.T) {
+	open := PolylinePath([]mgl32.Vec2{{0, 0}, {10, 0}, {10, 10}}, false)
+	closed := Rect(mgl32.Vec2{0, 0}, mgl32.Vec2{10, 10})
+	tests := []struct {
+		name           string
+		path           Path
+		distance       float32
+		point, tangent mgl32.Vec2
+	}{
+		{"start", open, 0, mgl32.Vec2{0, 0}, mgl32.Vec2{1, 0}},
+		{"before the start", open, -3, mgl32.Vec2{0, 0}, mgl32.Vec2{1, 0}},
+		{"first edge", open, 5, mgl32.Vec2{5, 0}, mgl32.Vec2{1, 0}},
+		{"second edge", open, 15, mgl32.Vec2{10, 5}, mgl32.Vec2{0, 1}},
+		{"past the end", open, 100, mgl32.Vec2{10, 10}, mgl32.Vec2{0, 1}},
+		{"closing edge", closed, 35, mgl32.Vec2{0, 5}, mgl32.Vec2{0, -1}},
+	}
+	for _, test := range tests {
+		point, tangent := test.path.PointAt(test.distance)
+		if !point.ApproxEqual(test.point) || !tangent.ApproxEqual(test.tangent) {
+			t.Errorf("%s: PointAt(%v) = %v %v, want %v %v", test.name, test.distance, point, tangent, test.point, test.tangent)
+		}
+	}
+}
+
+func TestPathContains(t *testing.T) {
+	nested := Path{}
+	for _, square := range [][2]float32{{0, 30}, {10, 20}} {
+		nested.MoveTo(mgl32.Vec2{square[0], square[0]})
+		nested.LineTo(mgl32.Vec2{square[1], square[0]})
+		nested.LineTo(mgl32.Vec2{square[1], square[1]})
+		nested.LineTo(mgl32.Vec2{square[0], square[1]})
+		nested.ClosePath()
+	}
+	triangle := PolylinePath([]mgl32.Vec2{{0, 0}, {10, 0}, {0, 10}}, false)
+	tests := []struct {
+		name             string
+		path             Path
+		point            mgl32.Vec2
+		nonZero, evenOdd bool
+	}{
+		{"outer ring", nested, mgl32.Vec2{5, 5}, true, true},
+		{"overlapping centre", nested, mgl32.Vec2{15, 15}, true, false},
+		{"outside", nested, mgl32.Vec2{35, 15}, false, false},
+		{"open triangle", triangle, mgl32.Vec2{2, 2}, true, true},
+		{"beyond the open edge", triangle, mgl32.Vec2{6, 6}, false, false},
+		{"empty", Path{}, mgl32.Vec2{0, 0}, false, false},
+	}
+	for _, test := range tests {
+		if got := test.path.Contains(test.point, FillNonZero); got != test.nonZero {
+			t.Errorf("%s: non-zero Contains(%v) = %v, want %v", test.name, test.point, got, test.nonZero)
+		}
+		if got := test.path.Contains(test.point, FillEvenOdd); got != test.evenOdd {
+			t.Errorf("%s: even-odd Contains(%v) = %v, want %v", test.name, test.point, got, test.evenOdd)
+		}
+	}
+}
+
+func TestPathClosestPoint(t *testing.T) {
+	rect := Rect(mgl32.Vec2{0, 0}, mgl32.Vec2{10, 10})
+	tests := []struct {
+		name           string
+		path           Path
+		point, closest mgl32.Vec2
+		distance       float64
+	}{
+		{"below an edge", rect, mgl32.Vec2{5, -3}, mgl32.Vec2{5, 0}, 3},
+		{"past a corner", rect, mgl32.Vec2{15, 15}, mgl32.Vec2{10, 10}, math.Sqrt(50)},
+		{"inside", rect, mgl32.Vec2{2, 5}, mgl32.Vec2{0, 5}, 2},
+		{"empty", Path{}, mgl32.Vec2{4, 2}, mgl32.Vec2{4, 2}, math.Inf(1)},
+	}
+	for _, test := range tests {
+		closest, distance := test.path.ClosestPoint(test.point)
+		if !closest.ApproxEqual(test.closest) || (float64(distance) != test.distance && math.Abs(float64(distance)-test.distance) > 1e-4) {
+			t.Errorf("%s: ClosestPoint(%v) = %v %v, want %v %v", test.name, test.point, closest, distance, test.closest, test.distance)
+		}
+	}
+}
@@ -71,6 +71,10 @@ func (program *Program) Bind(uniforms map[string]Uniform) {
 			gl.Uniform4fv(location, 1, &data[0])
 		case mgl32.Mat3:
 			gl.UniformMatrix3fv(location, 1, false, &data[0])
+		case []float32:
+			if len(data) > 0 {
+				gl.Uniform1fv(location, int32(len(data)), &data[0])
+			}
 		}
 	}
 }
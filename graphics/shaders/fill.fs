@@ -1,9 +1,9 @@
 #version 410 core
 
-out vec4 frag_color;
+#pragma paint
 
-uniform vec4 color;
+out vec4 frag_color;
 
 void main() {
-    frag_color = color;
-}
\ No newline at end of file
+    frag_color = paint();
+}
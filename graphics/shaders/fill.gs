@@ -6,10 +6,11 @@ layout (triangle_strip, max_vertices = 3) out;
 
 in vec2 pass_normal[];
 
+uniform mat3 transform;
 uniform vec2 origin;
 
 void main() {
-    gl_Position = vec4(origin, 0.0, 1.0);
+    gl_Position = vec4(transform * vec3(origin, 1.0), 1.0);
     EmitVertex();
     gl_Position = gl_in[0].gl_Position;
     EmitVertex();
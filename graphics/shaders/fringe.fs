@@ -0,0 +1,12 @@
+#version 410 core
+
+#pragma paint
+
+in float pass_coverage;
+
+out vec4 frag_color;
+
+void main() {
+    vec4 color = paint();
+    frag_color = vec4(color.rgb, color.a * pass_coverage);
+}
@@ -0,0 +1,32 @@
+#version 410 core
+
+layout(location = 0) in vec2 pos;
+layout(location = 1) in vec2 previous;
+layout(location = 2) in vec2 next;
+layout(location = 3) in float coverage;
+
+uniform mat3 transform;
+uniform vec2 viewport;
+uniform float fringe;
+
+out float pass_coverage;
+out vec2 pass_pos;
+
+vec2 screenNormal(vec2 normal) {
+    vec2 tangent = (transform * vec3(-normal.y, normal.x, 0.0)).xy * viewport;
+    if (length(tangent) > 0.0) {
+        return normalize(vec2(tangent.y, -tangent.x));
+    }
+    return vec2(0.0);
+}
+
+void main() {
+    vec3 position = transform * vec3(pos, 1.0);
+    vec2 n1 = screenNormal(previous);
+    vec2 n2 = screenNormal(next);
+    vec2 miter = (n1 + n2) / max(1.0 + dot(n1, n2), 0.25);
+    position.xy += miter * fringe * 2.0 / viewport;
+    gl_Position = vec4(position.xy, 0.0, 1.0);
+    pass_coverage = coverage;
+    pass_pos = pos;
+}
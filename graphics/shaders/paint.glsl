@@ -0,0 +1,19 @@
+in vec2 pass_pos;
+
+uniform vec4 color;
+uniform int paintMode;
+uniform mat3 paintTransform;
+uniform sampler2D paintSampler;
+
+vec4 paint() {
+    vec2 p = (paintTransform * vec3(pass_pos, 1.0)).xy;
+    if (paintMode == 1 || paintMode == 2) {
+        float t = paintMode == 1 ? p.x : length(p);
+        t = clamp(t, 0.0, 1.0);
+        return texture(paintSampler, vec2((t * 255.0 + 0.5) / 256.0, 0.5)) * color;
+    }
+    if (paintMode == 3) {
+        return texture(paintSampler, fract(p)) * color;
+    }
+    return color;
+}
@@ -6,8 +6,10 @@ layout(location = 1) in vec2 normal;
 uniform mat3 transform;
 
 out vec2 pass_normal;
+out vec2 pass_pos;
 
 void main() {
-    gl_Position = vec4(transform * vec3(pos, 0.0), 1.0);
+    gl_Position = vec4(transform * vec3(pos, 1.0), 1.0);
     pass_normal = normal;
+    pass_pos = pos;
 }
\ No newline at end of file
@@ -0,0 +1,7 @@
+#version 410 core
+
+out vec4 frag_color;
+
+void main() {
+    frag_color = vec4(0.0);
+}
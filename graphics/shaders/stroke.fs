@@ -1,16 +1,52 @@
 #version 410 core
 
+#pragma paint
+
 in float invWidth;
+in float pass_along;
+in float pass_across;
 
 out vec4 frag_color;
 
-uniform vec4 color;
+uniform float dashes[16];
+uniform int dashCount;
+uniform float dashOffset;
+uniform int dashCap;
+uniform float halfWidth;
 uniform float threshold;
 
+float dashDistance() {
+    float total = 0.0;
+    for (int i = 0; i < dashCount; i++) {
+        total += dashes[i];
+    }
+    float p = mod(pass_along + dashOffset, total);
+    float nearest = 1e20;
+    float start = 0.0;
+    for (int i = 0; i < dashCount; i += 2) {
+        for (int k = -1; k <= 1; k++) {
+            float s = start + float(k) * total;
+            float d = max(s - p, p - (s + dashes[i]));
+            if (dashCap == 1) {
+                d -= halfWidth;
+            } else if (dashCap == 2 && d > 0.0) {
+                d = length(vec2(d, pass_across)) - halfWidth;
+            }
+            nearest = min(nearest, d);
+        }
+        start += dashes[i] + dashes[i + 1];
+    }
+    return nearest;
+}
+
 void main() {
-    if (invWidth > threshold) {
-        frag_color = color;
-    } else {
-        frag_color = invWidth / threshold * color;
+    float coverage = clamp(invWidth / max(fwidth(invWidth), 1e-6), 0.0, 1.0);
+    if (dashCount > 0) {
+        float d = dashDistance();
+        coverage *= clamp(0.5 - d / max(fwidth(pass_along), 1e-6), 0.0, 1.0);
+    }
+    if (coverage < threshold) {
+        discard;
     }
-}
\ No newline at end of file
+    frag_color = coverage * paint();
+}
@@ -0,0 +1,21 @@
+#version 410 core
+
+layout(location = 0) in vec2 pos;
+layout(location = 1) in float edge;
+layout(location = 2) in float along;
+layout(location = 3) in float across;
+
+uniform mat3 transform;
+
+out float invWidth;
+out float pass_along;
+out float pass_across;
+out vec2 pass_pos;
+
+void main() {
+    gl_Position = vec4(transform * vec3(pos, 1.0), 1.0);
+    invWidth = edge;
+    pass_along = along;
+    pass_across = across;
+    pass_pos = pos;
+}
@@ -1,26 +1,38 @@
 #version 410 core
 
 in vec2 pass_uv;
+in vec4 pass_color;
+in float pass_threshold;
 
 out vec4 frag_color;
 
 uniform sampler2D textureSampler;
 
-uniform mat3 transform;
+uniform int mode;
 
 void main() {
-    float width = 0.5;
-    float thing = 0.0003;
-    float alias = (inverse(transform) * vec3(thing, thing, 0.0)).x;
-
     vec4 color = texture(textureSampler, pass_uv);
+
+    if (mode == 2) {
+        frag_color = color * pass_color;
+        return;
+    }
+
+    if (mode == 1) {
+        float coverage = color.a * max(color.r, max(color.g, color.b));
+        frag_color = vec4(pass_color.rgb, pass_color.a * coverage);
+        return;
+    }
+
+    float width = pass_threshold;
     float brightness = color.r;
+    float alias = max(fwidth(brightness), 0.0001);
 
     if (brightness < width - alias) {
-
+        discard;
     } else if (brightness < width) {
-        frag_color = vec4(0.0, 0.0, 0.0, (brightness - width + alias) / alias);
+        frag_color = vec4(pass_color.rgb, pass_color.a * (brightness - width + alias) / alias);
     } else {
-        frag_color = vec4(0.0, 0.0, 0.0, 1.0);
+        frag_color = pass_color;
     }
 }
\ No newline at end of file
@@ -2,12 +2,18 @@
 
 layout(location = 0) in vec2 pos;
 layout(location = 1) in vec2 uv;
+layout(location = 2) in vec4 color;
+layout(location = 3) in float threshold;
 
 out vec2 pass_uv;
+out vec4 pass_color;
+out float pass_threshold;
 
 uniform mat3 transform;
 
 void main() {
     gl_Position = vec4(transform * vec3(pos, 1.0), 1.0);
     pass_uv = uv;
+    pass_color = color;
+    pass_threshold = threshold;
 }
\ No newline at end of file
@@ -0,0 +1,131 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func pointOnEllipse(center, radius mgl32.Vec2, angle float32) mgl32.Vec2 {
+	sin, cos := math.Sincos(float64(angle))
+	return center.Add(mgl32.Vec2{radius.X() * float32(cos), radius.Y() * float32(sin)})
+}
+
+func (path *Path) ellipticalArc(center, radius mgl32.Vec2, startAngle, endAngle float32) {
+	sweep := endAngle > startAngle
+	pieces := int(math.Ceil(math.Abs(float64(endAngle-startAngle)) / math.Pi))
+	for i := 1; i <= pieces; i++ {
+		angle := startAngle + (endAngle-startAngle)*float32(i)/float32(pieces)
+		path.ArcTo(radius, 0, false, sweep, pointOnEllipse(center, radius, angle))
+	}
+}
+
+func Rect(position, size mgl32.Vec2) Path {
+	return PolylinePath([]mgl32.Vec2{
+		position,
+		position.Add(mgl32.Vec2{size.X(), 0}),
+		position.Add(size),
+		position.Add(mgl32.Vec2{0, size.Y()}),
+	}, true)
+}
+
+func RoundedRect(position, size mgl32.Vec2, radius float32) Path {
+	return roundedRect(position, size, mgl32.Vec2{radius, radius})
+}
+
+func roundedRect(position, size, radius mgl32.Vec2) Path {
+	rx := min32(radius.X(), size.X()/2)
+	ry := min32(radius.Y(), size.Y()/2)
+	if rx <= 0 || ry <= 0 {
+		return Rect(position, size)
+	}
+	x, y, width, height := position.X(), position.Y(), size.X(), size.Y()
+	corner := mgl32.Vec2{rx, ry}
+
+	path := Path{}
+	path.MoveTo(mgl32.Vec2{x + rx, y})
+	path.LineTo(mgl32.Vec2{x + width - rx, y})
+	path.ArcTo(corner, 0, false, true, mgl32.Vec2{x + width, y + ry})
+	path.LineTo(mgl32.Vec2{x + width, y + height - ry})
+	path.ArcTo(corner, 0, false, true, mgl32.Vec2{x + width - rx, y + height})
+	path.LineTo(mgl32.Vec2{x + rx, y + height})
+	path.ArcTo(corner, 0, false, true, mgl32.Vec2{x, y + height - ry})
+	path.LineTo(mgl32.Vec2{x, y + ry})
+	path.ArcTo(corner, 0, false, true, mgl32.Vec2{x + rx, y})
+	path.ClosePath()
+	return path
+}
+
+func Circle(center mgl32.Vec2, radius float32) Path {
+	return Ellipse(center, mgl32.Vec2{radius, radius})
+}
+
+func Ellipse(center, radius mgl32.Vec2) Path {
+	path := Path{}
+	path.MoveTo(pointOnEllipse(center, radius, 0))
+	path.ellipticalArc(center, radius, 0, 2*math.Pi)
+	path.ClosePath()
+	return path
+}
+
+func RegularPolygon(center mgl32.Vec2, radius float32, sides int, rotation float32) Path {
+	if sides < 3 {
+		sides = 3
+	}
+	points := make([]mgl32.Vec2, sides)
+	for i := range points {
+		angle := rotation + 2*math.Pi*float32(i)/float32(sides)
+		points[i] = pointOnEllipse(center, mgl32.Vec2{radius, radius}, angle)
+	}
+	return PolylinePath(points, true)
+}
+
+func Star(center mgl32.Vec2, outerRadius, innerRadius float32, points int, rotation float32) Path {
+	if points < 2 {
+		points = 2
+	}
+	vertices := make([]mgl32.Vec2, points*2)
+	for i := range vertices {
+		radius := outerRadius
+		if i%2 == 1 {
+			radius = innerRadius
+		}
+		angle := rotation + math.Pi*float32(i)/float32(points)
+		vertices[i] = pointOnEllipse(center, mgl32.Vec2{radius, radius}, angle)
+	}
+	return PolylinePath(vertices, true)
+}
+
+func Arc(center mgl32.Vec2, radius, startAngle, endAngle float32) Path {
+	path := Path{}
+	path.MoveTo(pointOnEllipse(center, mgl32.Vec2{radius, radius}, startAngle))
+	path.ellipticalArc(center, mgl32.Vec2{radius, radius}, startAngle, endAngle)
+	return path
+}
+
+func Pie(center mgl32.Vec2, radius, startAngle, endAngle float32) Path {
+	if math.Abs(float64(endAngle-startAngle)) >= 2*math.Pi {
+		return Circle(center, radius)
+	}
+	path := Path{}
+	path.MoveTo(center)
+	path.LineTo(pointOnEllipse(center, mgl32.Vec2{radius, radius}, startAngle))
+	path.ellipticalArc(center, mgl32.Vec2{radius, radius}, startAngle, endAngle)
+	path.ClosePath()
+	return path
+}
+
+func PolylinePath(points []mgl32.Vec2, closed bool) Path {
+	path := Path{}
+	for i, point := range points {
+		if i == 0 {
+			path.MoveTo(point)
+		} else {
+			path.LineTo(point)
+		}
+	}
+	if closed {
+		path.ClosePath()
+	}
+	return path
+}
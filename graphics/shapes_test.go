@@ -0,0 +1,70 @@
+package graphics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func shapeExtent(path Path) (mgl32.Vec2, mgl32.Vec2, float64) {
+	min := mgl32.Vec2{float32(math.Inf(1)), float32(math.Inf(1))}
+	max := min.Mul(-1)
+	area := 0.0
+	for _, polyline := range path.Flatten(0.01) {
+		for i, a := range polyline.Points {
+			b := polyline.Points[(i+1)%len(polyline.Points)]
+			area += float64(a.X()*b.Y() - b.X()*a.Y())
+			min = mgl32.Vec2{min32(min.X(), a.X()), min32(min.Y(), a.Y())}
+			max = mgl32.Vec2{max32(max.X(), a.X()), max32(max.Y(), a.Y())}
+		}
+	}
+	return min, max, math.Abs(area) / 2
+}
+
+func TestShapeBoundsAndArea(t *testing.T) {
+	hexagon := float32(10 * math.Sqrt(3) / 2)
+	tests := []struct {
+		name     string
+		path     Path
+		min, max mgl32.Vec2
+		area     float64
+	}{
+		{"rect", Rect(mgl32.Vec2{10, 20}, mgl32.Vec2{30, 40}), mgl32.Vec2{10, 20}, mgl32.Vec2{40, 60}, 1200},
+		{"rounded rect", RoundedRect(mgl32.Vec2{0, 0}, mgl32.Vec2{100, 50}, 10), mgl32.Vec2{0, 0}, mgl32.Vec2{100, 50}, 5000 - (4-math.Pi)*100},
+		{"clamped rounded rect", RoundedRect(mgl32.Vec2{0, 0}, mgl32.Vec2{100, 50}, 100), mgl32.Vec2{0, 0}, mgl32.Vec2{100, 50}, math.Pi * 50 * 25},
+		{"square rounded rect", RoundedRect(mgl32.Vec2{0, 0}, mgl32.Vec2{100, 50}, 0), mgl32.Vec2{0, 0}, mgl32.Vec2{100, 50}, 5000},
+		{"circle", Circle(mgl32.Vec2{5, 5}, 10), mgl32.Vec2{-5, -5}, mgl32.Vec2{15, 15}, math.Pi * 100},
+		{"ellipse", Ellipse(mgl32.Vec2{0, 0}, mgl32.Vec2{20, 10}), mgl32.Vec2{-20, -10}, mgl32.Vec2{20, 10}, math.Pi * 200},
+		{"hexagon", RegularPolygon(mgl32.Vec2{0, 0}, 10, 6, 0), mgl32.Vec2{-10, -hexagon}, mgl32.Vec2{10, hexagon}, 3 * math.Sqrt(3) / 2 * 100},
+		{"degenerate polygon", RegularPolygon(mgl32.Vec2{0, 0}, 10, 2, 0), mgl32.Vec2{-5, -hexagon}, mgl32.Vec2{10, hexagon}, 3 * math.Sqrt(3) / 4 * 100},
+		{"star", Star(mgl32.Vec2{0, 0}, 10, 5, 5, 0), mgl32.Vec2{float32(10 * math.Cos(0.8*math.Pi)), float32(-10 * math.Sin(0.4*math.Pi))}, mgl32.Vec2{10, float32(10 * math.Sin(0.4*math.Pi))}, 5 * 10 * 5 * math.Sin(math.Pi/5)},
+		{"pie", Pie(mgl32.Vec2{0, 0}, 10, 0, math.Pi/2), mgl32.Vec2{0, 0}, mgl32.Vec2{10, 10}, math.Pi * 25},
+		{"full pie", Pie(mgl32.Vec2{0, 0}, 10, 0, 3*math.Pi), mgl32.Vec2{-10, -10}, mgl32.Vec2{10, 10}, math.Pi * 100},
+	}
+	for _, test := range tests {
+		min, max, area := shapeExtent(test.path)
+		if !min.ApproxEqualThreshold(test.min, 0.01) || !max.ApproxEqualThreshold(test.max, 0.01) {
+			t.Errorf("%s: got bounds %v %v, want %v %v", test.name, min, max, test.min, test.max)
+		}
+		if math.Abs(area-test.area) > test.area*0.005 {
+			t.Errorf("%s: got area %v, want %v", test.name, area, test.area)
+		}
+	}
+}
+
+func TestArcIsOpen(t *testing.T) {
+	arc := Arc(mgl32.Vec2{0, 0}, 10, math.Pi/2, math.Pi)
+	polylines := arc.Flatten(0.01)
+	if len(polylines) != 1 || polylines[0].Closed {
+		t.Fatalf("got %d polylines, want a single open polyline", len(polylines))
+	}
+	min, max, _ := shapeExtent(arc)
+	if !min.ApproxEqualThreshold(mgl32.Vec2{-10, 0}, 0.01) || !max.ApproxEqualThreshold(mgl32.Vec2{0, 10}, 0.01) {
+		t.Errorf("got bounds %v %v, want [-10 0] [0 10]", min, max)
+	}
+	points := polylines[0].Points
+	if !points[0].ApproxEqualThreshold(mgl32.Vec2{0, 10}, 0.01) || !points[len(points)-1].ApproxEqualThreshold(mgl32.Vec2{-10, 0}, 0.01) {
+		t.Errorf("arc runs from %v to %v, want [0 10] to [-10 0]", points[0], points[len(points)-1])
+	}
+}
@@ -0,0 +1,316 @@
+package graphics
+
+import (
+	"math"
+	"unsafe"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+type LineJoin int
+
+const (
+	JoinMiter LineJoin = iota
+	JoinBevel
+	JoinRound
+)
+
+type LineCap int
+
+const (
+	CapButt LineCap = iota
+	CapSquare
+	CapRound
+)
+
+const DefaultMiterLimit = 4
+
+type StrokeStyle struct {
+	Width      float32
+	Join       LineJoin
+	Cap        LineCap
+	MiterLimit float32
+	Dashes     []float32
+	DashOffset float32
+}
+
+type strokeKey struct {
+	width      float32
+	join       LineJoin
+	cap        LineCap
+	miterLimit float32
+	cpuDashes  bool
+}
+
+type StrokeVertex struct {
+	pos    mgl32.Vec2
+	edge   float32
+	along  float32
+	across float32
+}
+
+type strokeMesh struct {
+	vbo        Buffer
+	vao        uint32
+	size       int
+	dashes     []float32
+	dashOffset float32
+	revision   int
+}
+
+type strokeBuilder struct {
+	vertices  []StrokeVertex
+	halfWidth float32
+	tolerance float32
+	style     StrokeStyle
+	frame     strokeFrame
+}
+
+type strokeFrame struct {
+	origin, direction, normal mgl32.Vec2
+	distance                  float32
+}
+
+const maxShaderDashes = 16
+
+func (path *Path) StrokeGeometry(style StrokeStyle) []StrokeVertex {
+	tolerance := path.tolerance
+	if tolerance <= 0 {
+		tolerance = DefaultPathTolerance
+	}
+	return StrokePolylines(path.Flatten(tolerance), style, tolerance)
+}
+
+func StrokePolylines(polylines []Polyline, style StrokeStyle, tolerance float32) []StrokeVertex {
+	return strokePolylines(DashPolylines(polylines, style.Dashes, style.DashOffset), style, tolerance)
+}
+
+func strokePolylines(polylines []Polyline, style StrokeStyle, tolerance float32) []StrokeVertex {
+	if style.MiterLimit <= 0 {
+		style.MiterLimit = DefaultMiterLimit
+	}
+	builder := strokeBuilder{halfWidth: style.Width / 2, tolerance: tolerance, style: style}
+	if builder.halfWidth <= 0 {
+		return nil
+	}
+	for _, polyline := range polylines {
+		builder.polyline(polyline)
+	}
+	return builder.vertices
+}
+
+func shaderDashes(dashes []float32) []float32 {
+	pattern := dashPattern(dashes)
+	if len(pattern) > maxShaderDashes {
+		return nil
+	}
+	return pattern
+}
+
+func (builder *strokeBuilder) vertex(pos mgl32.Vec2, edge float32) StrokeVertex {
+	offset := pos.Sub(builder.frame.origin)
+	return StrokeVertex{pos, edge, builder.frame.distance + offset.Dot(builder.frame.direction), offset.Dot(builder.frame.normal)}
+}
+
+func (builder *strokeBuilder) triangle(a, b, c mgl32.Vec2, edgeA, edgeB, edgeC float32) {
+	builder.vertices = append(builder.vertices, builder.vertex(a, edgeA), builder.vertex(b, edgeB), builder.vertex(c, edgeC))
+}
+
+func (builder *strokeBuilder) polyline(polyline Polyline) {
+	points := []mgl32.Vec2{}
+	for _, point := range polyline.Points {
+		if len(points) == 0 || !point.ApproxEqual(points[len(points)-1]) {
+			points = append(points, point)
+		}
+	}
+	closed := polyline.Closed
+	if closed && len(points) > 2 && points[0].ApproxEqual(points[len(points)-1]) {
+		points = points[:len(points)-1]
+	}
+	if len(points) == 1 && len(polyline.Points) > 1 && !closed {
+		builder.dot(points[0])
+		return
+	}
+	if len(points) < 2 {
+		return
+	}
+	if len(points) < 3 {
+		closed = false
+	}
+
+	segments := len(points) - 1
+	if closed {
+		segments = len(points)
+	}
+	hw := builder.halfWidth
+	distances := make([]float32, segments+1)
+	for i := 0; i < segments; i++ {
+		a, b := points[i], points[(i+1)%len(points)]
+		direction := b.Sub(a).Normalize()
+		normal := perp(direction)
+		builder.frame = strokeFrame{a, direction, normal, distances[i]}
+		distances[i+1] = distances[i] + b.Sub(a).Len()
+
+		offset := normal.Mul(hw)
+		builder.triangle(a, a.Add(offset), b, hw, 0, hw)
+		builder.triangle(a.Add(offset), b.Add(offset), b, 0, 0, hw)
+		builder.triangle(a, b, a.Sub(offset), hw, hw, 0)
+		builder.triangle(a.Sub(offset), b, b.Sub(offset), 0, hw, 0)
+	}
+
+	for i := 0; i < len(points); i++ {
+		if !closed && (i == 0 || i == len(points)-1) {
+			continue
+		}
+		prev := points[(i+len(points)-1)%len(points)]
+		next := points[(i+1)%len(points)]
+		builder.join(prev, points[i], next, distances[i])
+	}
+
+	if !closed {
+		start := points[1].Sub(points[0]).Normalize()
+		builder.frame = strokeFrame{points[0], start, perp(start), 0}
+		builder.cap(points[0], start.Mul(-1))
+		last := len(points) - 1
+		end := points[last].Sub(points[last-1]).Normalize()
+		builder.frame = strokeFrame{points[last], end, perp(end), distances[last]}
+		builder.cap(points[last], end)
+	}
+}
+
+func (builder *strokeBuilder) dot(point mgl32.Vec2) {
+	builder.frame = strokeFrame{point, mgl32.Vec2{1, 0}, mgl32.Vec2{0, -1}, 0}
+	builder.cap(point, mgl32.Vec2{1, 0})
+	builder.cap(point, mgl32.Vec2{-1, 0})
+}
+
+func (builder *strokeBuilder) join(prev, point, next mgl32.Vec2, distance float32) {
+	in := point.Sub(prev).Normalize()
+	out := next.Sub(point).Normalize()
+	cross := in.X()*out.Y() - in.Y()*out.X()
+	if math.Abs(float64(cross)) < 1e-6 && in.Dot(out) > 0 {
+		return
+	}
+
+	hw := builder.halfWidth
+	side := float32(1)
+	if cross < 0 {
+		side = -1
+	}
+	n1 := perp(in).Mul(side)
+	n2 := perp(out).Mul(side)
+	p1 := point.Add(n1.Mul(hw))
+	p2 := point.Add(n2.Mul(hw))
+	builder.frame = strokeFrame{point, mgl32.Vec2{0, 0}, mgl32.Vec2{0, 0}, distance}
+	if bisector := n1.Add(n2); !bisector.ApproxEqual(mgl32.Vec2{0, 0}) {
+		builder.frame.normal = bisector.Normalize()
+	}
+
+	switch builder.style.Join {
+	case JoinRound:
+		builder.fan(point, n1, math.Atan2(float64(n1.X()*n2.Y()-n1.Y()*n2.X()), float64(n1.Dot(n2))))
+		return
+	case JoinMiter:
+		miter := n1.Add(n2)
+		if !miter.ApproxEqual(mgl32.Vec2{0, 0}) {
+			miter = miter.Normalize()
+			ratio := 1 / miter.Dot(n1)
+			if ratio <= builder.style.MiterLimit {
+				tip := point.Add(miter.Mul(hw * ratio))
+				builder.triangle(point, p1, tip, hw, 0, 0)
+				builder.triangle(point, tip, p2, hw, 0, 0)
+				return
+			}
+		}
+	}
+	builder.triangle(point, p1, p2, hw, 0, 0)
+}
+
+func (builder *strokeBuilder) cap(point, direction mgl32.Vec2) {
+	hw := builder.halfWidth
+	normal := perp(direction)
+	switch builder.style.Cap {
+	case CapSquare:
+		extended := point.Add(direction.Mul(hw))
+		offset := normal.Mul(hw)
+		builder.triangle(point, point.Add(offset), extended, hw, 0, hw)
+		builder.triangle(point.Add(offset), extended.Add(offset), extended, 0, 0, hw)
+		builder.triangle(point, extended, point.Sub(offset), hw, hw, 0)
+		builder.triangle(point.Sub(offset), extended, extended.Sub(offset), 0, hw, 0)
+	case CapRound:
+		builder.fan(point, normal.Mul(-1), -math.Pi)
+	}
+}
+
+func (builder *strokeBuilder) fan(center, from mgl32.Vec2, angle float64) {
+	hw := builder.halfWidth
+	ratio := math.Max(1-float64(builder.tolerance/hw), -1)
+	step := 2 * math.Acos(ratio)
+	n := maxCurveSegments
+	if step > 0 {
+		n = clampSegments(math.Abs(angle) / step)
+	}
+
+	start := math.Atan2(float64(from.Y()), float64(from.X()))
+	prev := center.Add(from.Mul(hw))
+	for i := 1; i <= n; i++ {
+		sin, cos := math.Sincos(start + angle*float64(i)/float64(n))
+		point := center.Add(mgl32.Vec2{float32(cos), float32(sin)}.Mul(hw))
+		builder.triangle(center, prev, point, hw, 0, 0)
+		prev = point
+	}
+}
+
+func (style StrokeStyle) key() strokeKey {
+	pattern := dashPattern(style.Dashes)
+	return strokeKey{style.Width, style.Join, style.Cap, style.MiterLimit, pattern != nil && shaderDashes(style.Dashes) == nil}
+}
+
+func (path PathBuffer) stroke(style StrokeStyle) *strokeMesh {
+	key := style.key()
+	mesh, ok := path.strokes[key]
+	if ok && mesh.revision == path.revision {
+		if !key.cpuDashes || (equalDashes(mesh.dashes, style.Dashes) && mesh.dashOffset == style.DashOffset) {
+			return mesh
+		}
+	} else if !ok {
+		mesh = &strokeMesh{}
+		gl.CreateVertexArrays(1, &mesh.vao)
+		gl.BindVertexArray(mesh.vao)
+
+		mesh.vbo = CreateBuffer()
+		mesh.vbo.Bind()
+		gl.EnableVertexAttribArray(0)
+		gl.VertexAttribPointerWithOffset(0, 2, gl.FLOAT, false, int32(unsafe.Sizeof(StrokeVertex{})), unsafe.Offsetof(StrokeVertex{}.pos))
+		gl.EnableVertexAttribArray(1)
+		gl.VertexAttribPointerWithOffset(1, 1, gl.FLOAT, false, int32(unsafe.Sizeof(StrokeVertex{})), unsafe.Offsetof(StrokeVertex{}.edge))
+		gl.EnableVertexAttribArray(2)
+		gl.VertexAttribPointerWithOffset(2, 1, gl.FLOAT, false, int32(unsafe.Sizeof(StrokeVertex{})), unsafe.Offsetof(StrokeVertex{}.along))
+		gl.EnableVertexAttribArray(3)
+		gl.VertexAttribPointerWithOffset(3, 1, gl.FLOAT, false, int32(unsafe.Sizeof(StrokeVertex{})), unsafe.Offsetof(StrokeVertex{}.across))
+
+		if path.strokes != nil {
+			path.strokes[key] = mesh
+		}
+	}
+
+	vertices := strokePolylines(path.polylines, style, path.tolerance)
+	if key.cpuDashes {
+		vertices = StrokePolylines(path.polylines, style, path.tolerance)
+	}
+	mesh.size = len(vertices)
+	mesh.dashes = append(mesh.dashes[:0], style.Dashes...)
+	mesh.dashOffset = style.DashOffset
+	mesh.revision = path.revision
+	if len(vertices) > 0 {
+		mesh.vbo.Bind()
+		gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*int(unsafe.Sizeof(StrokeVertex{})), unsafe.Pointer(&vertices[0]), gl.DYNAMIC_DRAW)
+	}
+	return mesh
+}
+
+func (mesh strokeMesh) Delete() {
+	gl.DeleteVertexArrays(1, &mesh.vao)
+	mesh.vbo.Delete()
+}
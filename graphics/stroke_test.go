@@ -0,0 +1,98 @@
+package graphics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func strokeBounds(vertices []StrokeVertex) (mgl32.Vec2, mgl32.Vec2) {
+	min := mgl32.Vec2{float32(math.Inf(1)), float32(math.Inf(1))}
+	max := mgl32.Vec2{float32(math.Inf(-1)), float32(math.Inf(-1))}
+	for _, vertex := range vertices {
+		min = mgl32.Vec2{min32(min.X(), vertex.pos.X()), min32(min.Y(), vertex.pos.Y())}
+		max = mgl32.Vec2{max32(max.X(), vertex.pos.X()), max32(max.Y(), vertex.pos.Y())}
+	}
+	return min, max
+}
+
+func TestStrokeMiterLimit(t *testing.T) {
+	sharp := []Polyline{{Points: []mgl32.Vec2{{0, 0}, {10, 0}, {0, 1}}}}
+	tests := []struct {
+		style StrokeStyle
+		maxX  float32
+		miter bool
+	}{
+		{StrokeStyle{Width: 2, Join: JoinMiter, MiterLimit: 4}, 11, false},
+		{StrokeStyle{Width: 2, Join: JoinMiter, MiterLimit: 100}, 11, true},
+		{StrokeStyle{Width: 2, Join: JoinBevel, MiterLimit: 100}, 11, false},
+	}
+	for _, test := range tests {
+		_, max := strokeBounds(StrokePolylines(sharp, test.style, 0.25))
+		if extended := max.X() > test.maxX; extended != test.miter {
+			t.Errorf("%+v: max x %v, miter expected %v", test.style, max.X(), test.miter)
+		}
+	}
+
+	bevel := StrokePolylines(sharp, StrokeStyle{Width: 2, Join: JoinBevel}, 0.25)
+	fallback := StrokePolylines(sharp, StrokeStyle{Width: 2, Join: JoinMiter, MiterLimit: 4}, 0.25)
+	if len(bevel) != len(fallback) {
+		t.Errorf("miter fallback has %d vertices, bevel has %d", len(fallback), len(bevel))
+	}
+}
+
+func TestStrokeRoundSegments(t *testing.T) {
+	corner := []Polyline{{Points: []mgl32.Vec2{{0, 0}, {100, 0}, {100, 100}}}}
+	line := []Polyline{{Points: []mgl32.Vec2{{0, 0}, {100, 0}}}}
+	tests := []struct {
+		name      string
+		polylines []Polyline
+		style     StrokeStyle
+		tolerance float32
+		triangles int
+	}{
+		{"round join", corner, StrokeStyle{Width: 20, Join: JoinRound}, 0.25, 8 + 4},
+		{"round join fine", corner, StrokeStyle{Width: 20, Join: JoinRound}, 0.01, 8 + 18},
+		{"round caps", line, StrokeStyle{Width: 20, Cap: CapRound}, 0.25, 4 + 2*8},
+		{"round caps coarse", line, StrokeStyle{Width: 20, Cap: CapRound}, 5, 4 + 2*2},
+	}
+	for _, test := range tests {
+		vertices := StrokePolylines(test.polylines, test.style, test.tolerance)
+		if triangles := len(vertices) / 3; triangles != test.triangles {
+			t.Errorf("%s: got %d triangles, want %d", test.name, triangles, test.triangles)
+		}
+	}
+}
+
+func TestStrokeCaps(t *testing.T) {
+	line := []Polyline{{Points: []mgl32.Vec2{{0, 0}, {10, 0}}}}
+	tests := []struct {
+		cap      LineCap
+		min, max mgl32.Vec2
+	}{
+		{CapButt, mgl32.Vec2{0, -1}, mgl32.Vec2{10, 1}},
+		{CapSquare, mgl32.Vec2{-1, -1}, mgl32.Vec2{11, 1}},
+		{CapRound, mgl32.Vec2{-1, -1}, mgl32.Vec2{11, 1}},
+	}
+	for _, test := range tests {
+		min, max := strokeBounds(StrokePolylines(line, StrokeStyle{Width: 2, Cap: test.cap}, 0.01))
+		if !min.ApproxEqualThreshold(test.min, 1e-4) || !max.ApproxEqualThreshold(test.max, 1e-4) {
+			t.Errorf("cap %v: bounds %v %v, want %v %v", test.cap, min, max, test.min, test.max)
+		}
+	}
+}
+
+func TestStrokeClosedHasNoCaps(t *testing.T) {
+	square := []Polyline{{Points: []mgl32.Vec2{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}, Closed: true}}
+	butt := StrokePolylines(square, StrokeStyle{Width: 2, Join: JoinBevel, Cap: CapButt}, 0.25)
+	for _, cap := range []LineCap{CapSquare, CapRound} {
+		capped := StrokePolylines(square, StrokeStyle{Width: 2, Join: JoinBevel, Cap: cap}, 0.25)
+		if len(capped) != len(butt) {
+			t.Errorf("cap %v: closed polyline has %d vertices, want %d", cap, len(capped), len(butt))
+		}
+	}
+	if triangles := len(butt) / 3; triangles != 4*4+4 {
+		t.Errorf("closed square has %d triangles, want %d", triangles, 4*4+4)
+	}
+}
@@ -0,0 +1,460 @@
+package graphics
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+type SVGShape struct {
+	Path        Path
+	Fill        mgl32.Vec4
+	HasFill     bool
+	FillRule    FillRule
+	Antialias   bool
+	Stroke      mgl32.Vec4
+	HasStroke   bool
+	StrokeStyle StrokeStyle
+}
+
+type SVGDocument struct {
+	Width, Height float32
+	Shapes        []SVGShape
+}
+
+type SVGImage struct {
+	shapes  []SVGShape
+	buffers []PathBuffer
+}
+
+type svgState struct {
+	transform     mgl32.Mat3
+	color         mgl32.Vec4
+	fill          mgl32.Vec4
+	hasFill       bool
+	fillRule      FillRule
+	crispEdges    bool
+	stroke        mgl32.Vec4
+	hasStroke     bool
+	strokeStyle   StrokeStyle
+	opacity       float32
+	fillOpacity   float32
+	strokeOpacity float32
+	hidden        bool
+}
+
+var svgSkippedElements = map[string]bool{
+	"defs": true, "clipPath": true, "mask": true, "symbol": true, "pattern": true,
+	"linearGradient": true, "radialGradient": true, "marker": true, "style": true,
+	"title": true, "desc": true, "metadata": true, "text": true,
+}
+
+func LoadSVG(data []byte) (SVGDocument, error) {
+	document := SVGDocument{}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	stack := []svgState{{
+		transform:     mgl32.Ident3(),
+		color:         mgl32.Vec4{0, 0, 0, 1},
+		fill:          mgl32.Vec4{0, 0, 0, 1},
+		hasFill:       true,
+		strokeStyle:   StrokeStyle{Width: 1, MiterLimit: DefaultMiterLimit},
+		opacity:       1,
+		fillOpacity:   1,
+		strokeOpacity: 1,
+	}}
+	root := true
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return SVGDocument{}, fmt.Errorf("svg: %v", err)
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			name := element.Name.Local
+			if svgSkippedElements[name] {
+				if err := decoder.Skip(); err != nil {
+					return SVGDocument{}, fmt.Errorf("svg: %v", err)
+				}
+				continue
+			}
+
+			attributes := svgAttributes(element.Attr)
+			if attributes["display"] == "none" {
+				if err := decoder.Skip(); err != nil {
+					return SVGDocument{}, fmt.Errorf("svg: %v", err)
+				}
+				continue
+			}
+			state, err := stack[len(stack)-1].apply(attributes)
+			if err != nil {
+				return SVGDocument{}, err
+			}
+
+			if name == "svg" && root {
+				root = false
+				viewBox, err := document.setSize(attributes)
+				if err != nil {
+					return SVGDocument{}, err
+				}
+				state.transform = state.transform.Mul3(viewBox)
+			}
+			stack = append(stack, state)
+
+			if state.hidden {
+				continue
+			}
+			path, ok, err := svgElementPath(name, attributes)
+			if err != nil {
+				return SVGDocument{}, err
+			}
+			if ok {
+				document.Shapes = append(document.Shapes, state.shape(path))
+			}
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+
+	return document, nil
+}
+
+func svgAttributes(attrs []xml.Attr) map[string]string {
+	attributes := make(map[string]string, len(attrs))
+	for _, attr := range attrs {
+		attributes[attr.Name.Local] = strings.TrimSpace(attr.Value)
+	}
+	if style, ok := attributes["style"]; ok {
+		for _, declaration := range strings.Split(style, ";") {
+			colon := strings.IndexByte(declaration, ':')
+			if colon < 0 {
+				continue
+			}
+			attributes[strings.TrimSpace(declaration[:colon])] = strings.TrimSpace(declaration[colon+1:])
+		}
+	}
+	return attributes
+}
+
+func svgLength(str string) (float32, error) {
+	str = strings.TrimSuffix(strings.TrimSpace(str), "px")
+	value, err := strconv.ParseFloat(str, 32)
+	if err != nil {
+		return 0, fmt.Errorf("svg: invalid length '%s'", str)
+	}
+	return float32(value), nil
+}
+
+func svgLengths(attributes map[string]string, names ...string) ([]float32, error) {
+	values := make([]float32, len(names))
+	for i, name := range names {
+		str, ok := attributes[name]
+		if !ok || str == "" {
+			continue
+		}
+		value, err := svgLength(str)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func svgNumbers(str string) ([]float32, error) {
+	scanner := svgScanner{data: str}
+	values := []float32{}
+	for !scanner.done() {
+		value, err := scanner.number()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func (document *SVGDocument) setSize(attributes map[string]string) (mgl32.Mat3, error) {
+	if str, ok := attributes["width"]; ok && !strings.HasSuffix(str, "%") {
+		value, err := svgLength(str)
+		if err != nil {
+			return mgl32.Ident3(), err
+		}
+		document.Width = value
+	}
+	if str, ok := attributes["height"]; ok && !strings.HasSuffix(str, "%") {
+		value, err := svgLength(str)
+		if err != nil {
+			return mgl32.Ident3(), err
+		}
+		document.Height = value
+	}
+
+	str, ok := attributes["viewBox"]
+	if !ok {
+		return mgl32.Ident3(), nil
+	}
+	viewBox, err := svgNumbers(str)
+	if err != nil || len(viewBox) != 4 || viewBox[2] <= 0 || viewBox[3] <= 0 {
+		return mgl32.Ident3(), fmt.Errorf("svg: invalid viewBox '%s'", str)
+	}
+	if document.Width == 0 {
+		document.Width = viewBox[2]
+	}
+	if document.Height == 0 {
+		document.Height = viewBox[3]
+	}
+	scale := min32(document.Width/viewBox[2], document.Height/viewBox[3])
+	x := (document.Width-viewBox[2]*scale)/2 - viewBox[0]*scale
+	y := (document.Height-viewBox[3]*scale)/2 - viewBox[1]*scale
+	return mgl32.Translate2D(x, y).Mul3(mgl32.Scale2D(scale, scale)), nil
+}
+
+func parseSVGPaint(str string, current mgl32.Vec4) (mgl32.Vec4, bool, error) {
+	switch {
+	case str == "currentColor":
+		return current, true, nil
+	case str == "none" || str == "transparent":
+		return mgl32.Vec4{}, false, nil
+	case strings.HasPrefix(str, "url("):
+		end := strings.IndexByte(str, ')')
+		if end < 0 {
+			return mgl32.Vec4{}, false, fmt.Errorf("svg: invalid paint '%s'", str)
+		}
+		fallback := strings.TrimSpace(str[end+1:])
+		if fallback == "" {
+			return mgl32.Vec4{}, false, nil
+		}
+		return parseSVGPaint(fallback, current)
+	case strings.HasPrefix(str, "rgb(") && strings.HasSuffix(str, ")"):
+		parts := strings.Split(str[4:len(str)-1], ",")
+		if len(parts) != 3 {
+			return mgl32.Vec4{}, false, fmt.Errorf("svg: invalid color '%s'", str)
+		}
+		color := mgl32.Vec4{0, 0, 0, 1}
+		for i, part := range parts {
+			part = strings.TrimSpace(part)
+			scale := 255.0
+			if strings.HasSuffix(part, "%") {
+				part = part[:len(part)-1]
+				scale = 100
+			}
+			value, err := strconv.ParseFloat(part, 32)
+			if err != nil {
+				return mgl32.Vec4{}, false, fmt.Errorf("svg: invalid color '%s'", str)
+			}
+			color[i] = float32(math.Max(0, math.Min(1, value/scale)))
+		}
+		return color, true, nil
+	}
+	if color, ok := svgNamedColor(strings.ToLower(str)); ok {
+		return color, true, nil
+	}
+	color, err := ParseColor(str)
+	if err != nil {
+		return mgl32.Vec4{}, false, fmt.Errorf("svg: %v", err)
+	}
+	return color, true, nil
+}
+
+func (state svgState) apply(attributes map[string]string) (svgState, error) {
+	state.strokeStyle.Dashes = append([]float32{}, state.strokeStyle.Dashes...)
+	if value, ok := attributes["color"]; ok && value != "inherit" {
+		color, hasColor, err := parseSVGPaint(value, state.color)
+		if err != nil {
+			return state, err
+		}
+		if hasColor {
+			state.color = color
+		}
+	}
+
+	for name, value := range attributes {
+		if value == "inherit" {
+			continue
+		}
+		var err error
+		switch name {
+		case "transform":
+			var transform mgl32.Mat3
+			transform, err = parseTransform(value)
+			state.transform = state.transform.Mul3(transform)
+		case "fill":
+			state.fill, state.hasFill, err = parseSVGPaint(value, state.color)
+		case "fill-rule":
+			state.fillRule = FillNonZero
+			if value == "evenodd" {
+				state.fillRule = FillEvenOdd
+			}
+		case "shape-rendering":
+			state.crispEdges = value == "crispEdges" || value == "optimizeSpeed"
+		case "stroke":
+			state.stroke, state.hasStroke, err = parseSVGPaint(value, state.color)
+		case "stroke-width":
+			state.strokeStyle.Width, err = svgLength(value)
+		case "stroke-miterlimit":
+			state.strokeStyle.MiterLimit, err = svgLength(value)
+		case "stroke-dashoffset":
+			state.strokeStyle.DashOffset, err = svgLength(value)
+		case "stroke-dasharray":
+			state.strokeStyle.Dashes = nil
+			if value != "none" {
+				state.strokeStyle.Dashes, err = svgNumbers(value)
+			}
+		case "stroke-linejoin":
+			switch value {
+			case "round":
+				state.strokeStyle.Join = JoinRound
+			case "bevel":
+				state.strokeStyle.Join = JoinBevel
+			default:
+				state.strokeStyle.Join = JoinMiter
+			}
+		case "stroke-linecap":
+			switch value {
+			case "round":
+				state.strokeStyle.Cap = CapRound
+			case "square":
+				state.strokeStyle.Cap = CapSquare
+			default:
+				state.strokeStyle.Cap = CapButt
+			}
+		case "opacity":
+			var opacity float32
+			opacity, err = svgLength(value)
+			state.opacity *= opacity
+		case "fill-opacity":
+			state.fillOpacity, err = svgLength(value)
+		case "stroke-opacity":
+			state.strokeOpacity, err = svgLength(value)
+		case "visibility":
+			state.hidden = value == "hidden" || value == "collapse"
+		}
+		if err != nil {
+			return state, err
+		}
+	}
+	return state, nil
+}
+
+func (state svgState) shape(path Path) SVGShape {
+	scale := transformScale(state.transform)
+	shape := SVGShape{
+		Path:        path.Transform(state.transform),
+		Fill:        state.fill,
+		HasFill:     state.hasFill,
+		FillRule:    state.fillRule,
+		Antialias:   !state.crispEdges,
+		Stroke:      state.stroke,
+		HasStroke:   state.hasStroke && state.strokeStyle.Width > 0,
+		StrokeStyle: state.strokeStyle,
+	}
+	shape.Fill[3] *= state.opacity * state.fillOpacity
+	shape.Stroke[3] *= state.opacity * state.strokeOpacity
+	shape.StrokeStyle.Width *= scale
+	shape.StrokeStyle.DashOffset *= scale
+	shape.StrokeStyle.Dashes = make([]float32, len(state.strokeStyle.Dashes))
+	for i, dash := range state.strokeStyle.Dashes {
+		shape.StrokeStyle.Dashes[i] = dash * scale
+	}
+	return shape
+}
+
+func svgElementPath(name string, attributes map[string]string) (Path, bool, error) {
+	path := Path{}
+	switch name {
+	case "path":
+		p, err := ParsePathData(attributes["d"])
+		return p, err == nil, err
+	case "rect":
+		values, err := svgLengths(attributes, "x", "y", "width", "height", "rx", "ry")
+		if err != nil {
+			return path, false, err
+		}
+		x, y, width, height, rx, ry := values[0], values[1], values[2], values[3], values[4], values[5]
+		if width <= 0 || height <= 0 {
+			return path, false, nil
+		}
+		if _, ok := attributes["ry"]; !ok {
+			ry = rx
+		}
+		if _, ok := attributes["rx"]; !ok {
+			rx = ry
+		}
+		path = roundedRect(mgl32.Vec2{x, y}, mgl32.Vec2{width, height}, mgl32.Vec2{rx, ry})
+	case "circle", "ellipse":
+		values, err := svgLengths(attributes, "cx", "cy", "r", "rx", "ry")
+		if err != nil {
+			return path, false, err
+		}
+		cx, cy, rx, ry := values[0], values[1], values[3], values[4]
+		if name == "circle" {
+			rx, ry = values[2], values[2]
+		}
+		if rx <= 0 || ry <= 0 {
+			return path, false, nil
+		}
+		path = Ellipse(mgl32.Vec2{cx, cy}, mgl32.Vec2{rx, ry})
+	case "line":
+		values, err := svgLengths(attributes, "x1", "y1", "x2", "y2")
+		if err != nil {
+			return path, false, err
+		}
+		path.MoveTo(mgl32.Vec2{values[0], values[1]})
+		path.LineTo(mgl32.Vec2{values[2], values[3]})
+	case "polyline", "polygon":
+		values, err := svgNumbers(attributes["points"])
+		if err != nil {
+			return path, false, err
+		}
+		if len(values) < 4 {
+			return path, false, nil
+		}
+		points := make([]mgl32.Vec2, len(values)/2)
+		for i := range points {
+			points[i] = mgl32.Vec2{values[i*2], values[i*2+1]}
+		}
+		path = PolylinePath(points, name == "polygon")
+	default:
+		return path, false, nil
+	}
+	return path, true, nil
+}
+
+func CreateSVGImage(document SVGDocument) *SVGImage {
+	image := &SVGImage{shapes: document.Shapes}
+	for _, shape := range document.Shapes {
+		image.buffers = append(image.buffers, shape.Path.ToBuffer())
+	}
+	return image
+}
+
+func (image *SVGImage) Render(renderer *PathRenderer, transform mgl32.Mat3) {
+	for i, shape := range image.shapes {
+		if shape.HasFill {
+			renderer.Fill(image.buffers[i], transform, SolidPaint(shape.Fill), FillStyle{Rule: shape.FillRule, Antialias: shape.Antialias})
+		}
+		if shape.HasStroke {
+			renderer.Stroke(image.buffers[i], transform, SolidPaint(shape.Stroke), shape.StrokeStyle)
+		}
+	}
+}
+
+func (image *SVGImage) Delete() {
+	for _, buffer := range image.buffers {
+		buffer.Delete()
+	}
+	image.buffers = nil
+}
@@ -0,0 +1,65 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestParseSVGPaint(t *testing.T) {
+	current := mgl32.Vec4{0.25, 0.5, 0.75, 1}
+	tests := []struct {
+		paint    string
+		color    mgl32.Vec4
+		hasColor bool
+	}{
+		{"none", mgl32.Vec4{}, false},
+		{"currentColor", current, true},
+		{"#ff0000", mgl32.Vec4{1, 0, 0, 1}, true},
+		{"rgb(0, 255, 0)", mgl32.Vec4{0, 1, 0, 1}, true},
+		{"silver", mgl32.Vec4{192.0 / 255, 192.0 / 255, 192.0 / 255, 1}, true},
+		{"navy", mgl32.Vec4{0, 0, 128.0 / 255, 1}, true},
+		{"Green", mgl32.Vec4{0, 128.0 / 255, 0, 1}, true},
+		{"url(#gradient)", mgl32.Vec4{}, false},
+		{"url(#gradient) navy", mgl32.Vec4{0, 0, 128.0 / 255, 1}, true},
+		{"url(#gradient) currentColor", current, true},
+	}
+	for _, test := range tests {
+		color, hasColor, err := parseSVGPaint(test.paint, current)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", test.paint, err)
+			continue
+		}
+		if hasColor != test.hasColor || !color.ApproxEqual(test.color) {
+			t.Errorf("%s: got %v %v, want %v %v", test.paint, color, hasColor, test.color, test.hasColor)
+		}
+	}
+
+	if _, _, err := parseSVGPaint("notacolor", current); err == nil {
+		t.Error("expected an error for an unknown color")
+	}
+}
+
+func TestLoadSVGGradientFill(t *testing.T) {
+	document, err := LoadSVG([]byte(`<svg xmlns="http://www.w3.org/2000/svg" width="20" height="20">
+		<defs><linearGradient id="gradient"><stop offset="0" stop-color="red"/></linearGradient></defs>
+		<rect width="10" height="10" fill="url(#gradient)"/>
+		<rect width="10" height="10" fill="url(#gradient) silver"/>
+		<path d="M0 0 h10 v10 z" fill="navy"/>
+	</svg>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(document.Shapes) != 3 {
+		t.Fatalf("got %d shapes, want 3", len(document.Shapes))
+	}
+	if document.Shapes[0].HasFill {
+		t.Error("gradient fill without a fallback should be skipped")
+	}
+	if !document.Shapes[1].HasFill || !document.Shapes[1].Fill.ApproxEqual(mgl32.Vec4{192.0 / 255, 192.0 / 255, 192.0 / 255, 1}) {
+		t.Errorf("gradient fallback fill is %v", document.Shapes[1].Fill)
+	}
+	if !document.Shapes[2].HasFill || !document.Shapes[2].Fill.ApproxEqual(mgl32.Vec4{0, 0, 128.0 / 255, 1}) {
+		t.Errorf("named fill is %v", document.Shapes[2].Fill)
+	}
+}
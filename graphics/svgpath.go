@@ -0,0 +1,302 @@
+package graphics
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+type svgScanner struct {
+	data string
+	pos  int
+}
+
+func (scanner *svgScanner) skipSeparators() {
+	for scanner.pos < len(scanner.data) {
+		switch scanner.data[scanner.pos] {
+		case ' ', '\t', '\n', '\r', ',':
+			scanner.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (scanner *svgScanner) done() bool {
+	scanner.skipSeparators()
+	return scanner.pos >= len(scanner.data)
+}
+
+func (scanner *svgScanner) hasNumber() bool {
+	if scanner.done() {
+		return false
+	}
+	c := scanner.data[scanner.pos]
+	return c == '-' || c == '+' || c == '.' || (c >= '0' && c <= '9')
+}
+
+func (scanner *svgScanner) number() (float32, error) {
+	scanner.skipSeparators()
+	start := scanner.pos
+	i := scanner.pos
+	if i < len(scanner.data) && (scanner.data[i] == '-' || scanner.data[i] == '+') {
+		i++
+	}
+	digits := false
+	for i < len(scanner.data) && scanner.data[i] >= '0' && scanner.data[i] <= '9' {
+		i++
+		digits = true
+	}
+	if i < len(scanner.data) && scanner.data[i] == '.' {
+		i++
+		for i < len(scanner.data) && scanner.data[i] >= '0' && scanner.data[i] <= '9' {
+			i++
+			digits = true
+		}
+	}
+	if digits && i < len(scanner.data) && (scanner.data[i] == 'e' || scanner.data[i] == 'E') {
+		j := i + 1
+		if j < len(scanner.data) && (scanner.data[j] == '-' || scanner.data[j] == '+') {
+			j++
+		}
+		if j < len(scanner.data) && scanner.data[j] >= '0' && scanner.data[j] <= '9' {
+			for j < len(scanner.data) && scanner.data[j] >= '0' && scanner.data[j] <= '9' {
+				j++
+			}
+			i = j
+		}
+	}
+	if !digits {
+		return 0, fmt.Errorf("svg: expected number at offset %d", start)
+	}
+	value, err := strconv.ParseFloat(scanner.data[start:i], 32)
+	if err != nil {
+		return 0, fmt.Errorf("svg: invalid number at offset %d", start)
+	}
+	scanner.pos = i
+	return float32(value), nil
+}
+
+func (scanner *svgScanner) flag() (bool, error) {
+	scanner.skipSeparators()
+	if scanner.pos < len(scanner.data) {
+		switch scanner.data[scanner.pos] {
+		case '0':
+			scanner.pos++
+			return false, nil
+		case '1':
+			scanner.pos++
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("svg: expected flag at offset %d", scanner.pos)
+}
+
+func (scanner *svgScanner) numbers(values ...*float32) error {
+	for _, value := range values {
+		v, err := scanner.number()
+		if err != nil {
+			return err
+		}
+		*value = v
+	}
+	return nil
+}
+
+func ParsePathData(data string) (Path, error) {
+	path := Path{}
+	scanner := svgScanner{data: data}
+	current := mgl32.Vec2{0, 0}
+	start := current
+	control := current
+	command := byte(0)
+	previous := byte(0)
+
+	for !scanner.done() {
+		c := scanner.data[scanner.pos]
+		if strings.IndexByte("MmLlHhVvCcSsQqTtAaZz", c) >= 0 {
+			command = c
+			scanner.pos++
+		} else if command == 0 || !scanner.hasNumber() {
+			return Path{}, fmt.Errorf("svg: unexpected '%c' at offset %d", c, scanner.pos)
+		}
+
+		relative := command >= 'a'
+		offset := mgl32.Vec2{0, 0}
+		if relative {
+			offset = current
+		}
+		point := func() (mgl32.Vec2, error) {
+			var x, y float32
+			err := scanner.numbers(&x, &y)
+			return mgl32.Vec2{x, y}.Add(offset), err
+		}
+
+		switch command {
+		case 'M', 'm':
+			p, err := point()
+			if err != nil {
+				return Path{}, err
+			}
+			path.MoveTo(p)
+			current, start = p, p
+			if relative {
+				command = 'l'
+			} else {
+				command = 'L'
+			}
+		case 'L', 'l':
+			p, err := point()
+			if err != nil {
+				return Path{}, err
+			}
+			path.LineTo(p)
+			current = p
+		case 'H', 'h':
+			x, err := scanner.number()
+			if err != nil {
+				return Path{}, err
+			}
+			p := mgl32.Vec2{x + offset.X(), current.Y()}
+			path.LineTo(p)
+			current = p
+		case 'V', 'v':
+			y, err := scanner.number()
+			if err != nil {
+				return Path{}, err
+			}
+			p := mgl32.Vec2{current.X(), y + offset.Y()}
+			path.LineTo(p)
+			current = p
+		case 'C', 'c', 'S', 's':
+			c1 := current
+			if command == 'S' || command == 's' {
+				if strings.IndexByte("CcSs", previous) >= 0 {
+					c1 = current.Mul(2).Sub(control)
+				}
+			} else {
+				p, err := point()
+				if err != nil {
+					return Path{}, err
+				}
+				c1 = p
+			}
+			c2, err := point()
+			if err != nil {
+				return Path{}, err
+			}
+			p, err := point()
+			if err != nil {
+				return Path{}, err
+			}
+			path.CubicTo(c1, c2, p)
+			control, current = c2, p
+		case 'Q', 'q', 'T', 't':
+			c1 := current
+			if command == 'T' || command == 't' {
+				if strings.IndexByte("QqTt", previous) >= 0 {
+					c1 = current.Mul(2).Sub(control)
+				}
+			} else {
+				p, err := point()
+				if err != nil {
+					return Path{}, err
+				}
+				c1 = p
+			}
+			p, err := point()
+			if err != nil {
+				return Path{}, err
+			}
+			path.QuadraticTo(c1, p)
+			control, current = c1, p
+		case 'A', 'a':
+			var rx, ry, rotation float32
+			if err := scanner.numbers(&rx, &ry, &rotation); err != nil {
+				return Path{}, err
+			}
+			largeArc, err := scanner.flag()
+			if err != nil {
+				return Path{}, err
+			}
+			sweep, err := scanner.flag()
+			if err != nil {
+				return Path{}, err
+			}
+			p, err := point()
+			if err != nil {
+				return Path{}, err
+			}
+			path.ArcTo(mgl32.Vec2{rx, ry}, mgl32.DegToRad(rotation), largeArc, sweep, p)
+			current = p
+		case 'Z', 'z':
+			path.ClosePath()
+			current = start
+			previous = command
+			command = 0
+			continue
+		}
+		previous = command
+	}
+
+	return path, nil
+}
+
+func parseTransform(data string) (mgl32.Mat3, error) {
+	transform := mgl32.Ident3()
+	data = strings.TrimSpace(data)
+	for data != "" {
+		open := strings.IndexByte(data, '(')
+		end := strings.IndexByte(data, ')')
+		if open < 0 || end < open {
+			return transform, fmt.Errorf("svg: invalid transform '%s'", data)
+		}
+		name := strings.TrimSpace(data[:open])
+		scanner := svgScanner{data: data[open+1 : end]}
+		args := []float32{}
+		for scanner.hasNumber() {
+			value, err := scanner.number()
+			if err != nil {
+				return transform, err
+			}
+			args = append(args, value)
+		}
+		data = strings.TrimLeft(data[end+1:], " \t\n\r,")
+
+		arg := func(i int, fallback float32) float32 {
+			if i < len(args) {
+				return args[i]
+			}
+			return fallback
+		}
+
+		m := mgl32.Ident3()
+		switch name {
+		case "matrix":
+			if len(args) != 6 {
+				return transform, fmt.Errorf("svg: matrix needs 6 values")
+			}
+			m = mgl32.Mat3{args[0], args[1], 0, args[2], args[3], 0, args[4], args[5], 1}
+		case "translate":
+			m = mgl32.Translate2D(arg(0, 0), arg(1, 0))
+		case "scale":
+			sx := arg(0, 1)
+			m = mgl32.Scale2D(sx, arg(1, sx))
+		case "rotate":
+			angle := mgl32.DegToRad(arg(0, 0))
+			cx, cy := arg(1, 0), arg(2, 0)
+			m = mgl32.Translate2D(cx, cy).Mul3(mgl32.HomogRotate2D(angle)).Mul3(mgl32.Translate2D(-cx, -cy))
+		case "skewX":
+			m[3] = float32(math.Tan(float64(mgl32.DegToRad(arg(0, 0)))))
+		case "skewY":
+			m[1] = float32(math.Tan(float64(mgl32.DegToRad(arg(0, 0)))))
+		default:
+			return transform, fmt.Errorf("svg: unknown transform '%s'", name)
+		}
+		transform = transform.Mul3(m)
+	}
+	return transform, nil
+}
@@ -0,0 +1,165 @@
+package graphics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+type segmentSummary struct {
+	kind pathSegmentKind
+	end  mgl32.Vec2
+}
+
+func summarizeSegments(path Path) []segmentSummary {
+	summary := []segmentSummary{}
+	for _, segment := range path.segments {
+		end := segment.points[0]
+		switch segment.kind {
+		case segmentLine, segmentArc, segmentClose:
+			end = segment.points[1]
+		case segmentQuadratic:
+			end = segment.points[2]
+		case segmentCubic:
+			end = segment.points[3]
+		}
+		summary = append(summary, segmentSummary{segment.kind, end})
+	}
+	return summary
+}
+
+func TestParsePathData(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		segments []segmentSummary
+	}{
+		{"absolute lines", "M0 0 L10 0 L10 10 Z", []segmentSummary{
+			{segmentMove, mgl32.Vec2{0, 0}},
+			{segmentLine, mgl32.Vec2{10, 0}},
+			{segmentLine, mgl32.Vec2{10, 10}},
+			{segmentClose, mgl32.Vec2{0, 0}},
+		}},
+		{"relative lines", "m5 5 l10 0 h-5 v5 z", []segmentSummary{
+			{segmentMove, mgl32.Vec2{5, 5}},
+			{segmentLine, mgl32.Vec2{15, 5}},
+			{segmentLine, mgl32.Vec2{10, 5}},
+			{segmentLine, mgl32.Vec2{10, 10}},
+			{segmentClose, mgl32.Vec2{5, 5}},
+		}},
+		{"implicit repeats", "M0 0 10 0 10 10 l-10 0 0-10", []segmentSummary{
+			{segmentMove, mgl32.Vec2{0, 0}},
+			{segmentLine, mgl32.Vec2{10, 0}},
+			{segmentLine, mgl32.Vec2{10, 10}},
+			{segmentLine, mgl32.Vec2{0, 10}},
+			{segmentLine, mgl32.Vec2{0, 0}},
+		}},
+		{"relative after close", "M10 10 l5 0 z l0 5", []segmentSummary{
+			{segmentMove, mgl32.Vec2{10, 10}},
+			{segmentLine, mgl32.Vec2{15, 10}},
+			{segmentClose, mgl32.Vec2{10, 10}},
+			{segmentLine, mgl32.Vec2{10, 15}},
+		}},
+		{"compact numbers", "M.5.5L-1-1e1", []segmentSummary{
+			{segmentMove, mgl32.Vec2{0.5, 0.5}},
+			{segmentLine, mgl32.Vec2{-1, -10}},
+		}},
+		{"cubic repeats", "M0 0 c0 10 10 10 10 0 0-10 10-10 10 0", []segmentSummary{
+			{segmentMove, mgl32.Vec2{0, 0}},
+			{segmentCubic, mgl32.Vec2{10, 0}},
+			{segmentCubic, mgl32.Vec2{20, 0}},
+		}},
+		{"compact arc flags", "M0 0 a5 5 0 1010 0", []segmentSummary{
+			{segmentMove, mgl32.Vec2{0, 0}},
+			{segmentArc, mgl32.Vec2{10, 0}},
+		}},
+	}
+	for _, test := range tests {
+		path, err := ParsePathData(test.data)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+			continue
+		}
+		segments := summarizeSegments(path)
+		if len(segments) != len(test.segments) {
+			t.Errorf("%s: got %d segments, want %d", test.name, len(segments), len(test.segments))
+			continue
+		}
+		for i, segment := range segments {
+			if segment.kind != test.segments[i].kind || !segment.end.ApproxEqual(test.segments[i].end) {
+				t.Errorf("%s: segment %d is %v, want %v", test.name, i, segment, test.segments[i])
+			}
+		}
+	}
+}
+
+func TestParsePathDataReflection(t *testing.T) {
+	path, err := ParsePathData("M0 0 C0 10 10 10 10 0 S20-10 20 0 Q25 10 30 0 T40 0 M0 0 S5 5 10 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		segment int
+		control mgl32.Vec2
+	}{
+		{2, mgl32.Vec2{10, -10}},
+		{4, mgl32.Vec2{35, -10}},
+		{6, mgl32.Vec2{0, 0}},
+	}
+	for _, test := range tests {
+		if control := path.segments[test.segment].points[1]; !control.ApproxEqual(test.control) {
+			t.Errorf("segment %d: reflected control %v, want %v", test.segment, control, test.control)
+		}
+	}
+}
+
+func TestParsePathDataArcFlags(t *testing.T) {
+	tests := []struct {
+		data  string
+		large bool
+		sweep bool
+	}{
+		{"M0 0 A10 10 0 0 0 10 0", false, false},
+		{"M0 0 A10 10 0 0 1 10 0", false, true},
+		{"M0 0 A10 10 0 1 0 10 0", true, false},
+		{"M0 0 A10 10 0 1 1 10 0", true, true},
+	}
+	for _, test := range tests {
+		path, err := ParsePathData(test.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		arc := path.segments[1].arc
+		if large := arc.deltaAngle > 3.14159 || arc.deltaAngle < -3.14159; large != test.large {
+			t.Errorf("%s: large arc %v, want %v (delta %v)", test.data, large, test.large, arc.deltaAngle)
+		}
+		if sweep := arc.deltaAngle > 0; sweep != test.sweep {
+			t.Errorf("%s: sweep %v, want %v (delta %v)", test.data, sweep, test.sweep, arc.deltaAngle)
+		}
+	}
+}
+
+func TestParsePathDataErrors(t *testing.T) {
+	for _, data := range []string{
+		"M0 0 L10 0 L10 10 Z 5 5",
+		"M0 0 L10",
+		"0 0",
+		"M0 0 X10 10",
+		"M0 0 A5 5 0 2 0 10 0",
+	} {
+		result := make(chan error, 1)
+		go func() {
+			_, err := ParsePathData(data)
+			result <- err
+		}()
+		select {
+		case err := <-result:
+			if err == nil {
+				t.Errorf("%q: expected an error", data)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%q: parser did not return", data)
+		}
+	}
+}
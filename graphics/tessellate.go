@@ -0,0 +1,341 @@
+package graphics
+
+import (
+	"math"
+	"sort"
+	"unsafe"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+type TriangleMesh struct {
+	Vertices []mgl32.Vec2
+	Indices  []uint32
+}
+
+type tessEdge struct {
+	x0, y0, x1, y1 float64
+	winding        int
+}
+
+type slabEdge struct {
+	top, bottom, middle float64
+	winding             int
+}
+
+type tessMesh struct {
+	vbo      Buffer
+	vao, ibo uint32
+	size     int
+	revision int
+}
+
+func (path *Path) Tessellate(rule FillRule) TriangleMesh {
+	return Tessellate(path.Flatten(path.flatTolerance()), rule)
+}
+
+func Tessellate(polylines []Polyline, rule FillRule) TriangleMesh {
+	polygons := [][]mgl32.Vec2{}
+	for _, polyline := range polylines {
+		points := []mgl32.Vec2{}
+		for _, point := range polyline.Points {
+			if len(points) == 0 || !point.ApproxEqual(points[len(points)-1]) {
+				points = append(points, point)
+			}
+		}
+		if len(points) > 1 && points[0].ApproxEqual(points[len(points)-1]) {
+			points = points[:len(points)-1]
+		}
+		if len(points) >= 3 {
+			polygons = append(polygons, points)
+		}
+	}
+
+	if len(polygons) == 1 && isSimplePolygon(polygons[0]) {
+		if mesh, ok := earClip(polygons[0]); ok {
+			return mesh
+		}
+	}
+	return sweepTessellate(polygons, rule)
+}
+
+func cross2(o, a, b mgl32.Vec2) float64 {
+	return float64(a.X()-o.X())*float64(b.Y()-o.Y()) - float64(a.Y()-o.Y())*float64(b.X()-o.X())
+}
+
+func segmentsIntersect(a, b, c, d mgl32.Vec2) bool {
+	d1 := cross2(c, d, a)
+	d2 := cross2(c, d, b)
+	d3 := cross2(a, b, c)
+	d4 := cross2(a, b, d)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	onSegment := func(p, q, r mgl32.Vec2) bool {
+		return min32(p.X(), q.X()) <= r.X() && r.X() <= max32(p.X(), q.X()) &&
+			min32(p.Y(), q.Y()) <= r.Y() && r.Y() <= max32(p.Y(), q.Y())
+	}
+	return (d1 == 0 && onSegment(c, d, a)) || (d2 == 0 && onSegment(c, d, b)) ||
+		(d3 == 0 && onSegment(a, b, c)) || (d4 == 0 && onSegment(a, b, d))
+}
+
+func isSimplePolygon(points []mgl32.Vec2) bool {
+	n := len(points)
+	for i := 0; i < n; i++ {
+		a, b := points[i], points[(i+1)%n]
+		for j := i + 1; j < n; j++ {
+			if j == i+1 || (i == 0 && j == n-1) {
+				continue
+			}
+			if segmentsIntersect(a, b, points[j], points[(j+1)%n]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func earClip(points []mgl32.Vec2) (TriangleMesh, bool) {
+	n := len(points)
+	area := 0.0
+	for i := range points {
+		a, b := points[i], points[(i+1)%n]
+		area += float64(a.X())*float64(b.Y()) - float64(b.X())*float64(a.Y())
+	}
+
+	remaining := make([]uint32, n)
+	for i := range remaining {
+		remaining[i] = uint32(i)
+		if area < 0 {
+			remaining[i] = uint32(n - 1 - i)
+		}
+	}
+
+	mesh := TriangleMesh{Vertices: append([]mgl32.Vec2{}, points...)}
+	for guard := 0; len(remaining) > 3; guard++ {
+		if guard > n*n {
+			return TriangleMesh{}, false
+		}
+		found := false
+		for i := range remaining {
+			count := len(remaining)
+			prev, current, next := remaining[(i+count-1)%count], remaining[i], remaining[(i+1)%count]
+			a, b, c := points[prev], points[current], points[next]
+			turn := cross2(a, b, c)
+			if turn < 0 {
+				continue
+			}
+			if turn == 0 {
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				found = true
+				break
+			}
+			ear := true
+			for _, other := range remaining {
+				if other == prev || other == current || other == next {
+					continue
+				}
+				p := points[other]
+				if cross2(a, b, p) >= 0 && cross2(b, c, p) >= 0 && cross2(c, a, p) >= 0 {
+					ear = false
+					break
+				}
+			}
+			if ear {
+				mesh.Indices = append(mesh.Indices, prev, current, next)
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return TriangleMesh{}, false
+		}
+	}
+	if len(remaining) == 3 && cross2(points[remaining[0]], points[remaining[1]], points[remaining[2]]) > 0 {
+		mesh.Indices = append(mesh.Indices, remaining...)
+	}
+	return mesh, true
+}
+
+func sweepTessellate(polygons [][]mgl32.Vec2, rule FillRule) TriangleMesh {
+	edges := []tessEdge{}
+	ys := []float64{}
+	for _, polygon := range polygons {
+		for i := range polygon {
+			a, b := polygon[i], polygon[(i+1)%len(polygon)]
+			ys = append(ys, float64(a.Y()))
+			if a.Y() == b.Y() {
+				continue
+			}
+			edge := tessEdge{float64(a.X()), float64(a.Y()), float64(b.X()), float64(b.Y()), 1}
+			if edge.y0 > edge.y1 {
+				edge = tessEdge{edge.x1, edge.y1, edge.x0, edge.y0, -1}
+			}
+			edges = append(edges, edge)
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		return edges[i].y0 < edges[j].y0
+	})
+
+	for i := range edges {
+		for j := i + 1; j < len(edges) && edges[j].y0 < edges[i].y1; j++ {
+			if y, ok := edgeIntersection(edges[i], edges[j]); ok {
+				ys = append(ys, y)
+			}
+		}
+	}
+	sort.Float64s(ys)
+	unique := ys[:0]
+	for _, y := range ys {
+		if len(unique) == 0 || y > unique[len(unique)-1] {
+			unique = append(unique, y)
+		}
+	}
+	ys = unique
+
+	mesh := TriangleMesh{}
+	lookup := map[mgl32.Vec2]uint32{}
+	vertex := func(x, y float64) uint32 {
+		point := mgl32.Vec2{float32(x), float32(y)}
+		if index, ok := lookup[point]; ok {
+			return index
+		}
+		index := uint32(len(mesh.Vertices))
+		mesh.Vertices = append(mesh.Vertices, point)
+		lookup[point] = index
+		return index
+	}
+	inside := func(winding int) bool {
+		if rule == FillEvenOdd {
+			return winding%2 != 0
+		}
+		return winding != 0
+	}
+
+	active := []tessEdge{}
+	next := 0
+	slab := []slabEdge{}
+	for s := 0; s+1 < len(ys); s++ {
+		y0, y1 := ys[s], ys[s+1]
+		for next < len(edges) && edges[next].y0 <= y0 {
+			active = append(active, edges[next])
+			next++
+		}
+		kept := active[:0]
+		for _, edge := range active {
+			if edge.y1 > y0 {
+				kept = append(kept, edge)
+			}
+		}
+		active = kept
+
+		slab = slab[:0]
+		middle := (y0 + y1) / 2
+		for _, edge := range active {
+			if edge.y0 > y0 || edge.y1 < y1 {
+				continue
+			}
+			slab = append(slab, slabEdge{edge.xAt(y0), edge.xAt(y1), edge.xAt(middle), edge.winding})
+		}
+		sort.Slice(slab, func(i, j int) bool {
+			return slab[i].middle < slab[j].middle
+		})
+
+		winding := 0
+		left := 0
+		for i, edge := range slab {
+			before := inside(winding)
+			winding += edge.winding
+			after := inside(winding)
+			if !before && after {
+				left = i
+			} else if before && !after {
+				l, r := slab[left], edge
+				a, b := vertex(l.top, y0), vertex(r.top, y0)
+				c, d := vertex(r.bottom, y1), vertex(l.bottom, y1)
+				if a != b {
+					mesh.Indices = append(mesh.Indices, a, b, c)
+				}
+				if c != d {
+					mesh.Indices = append(mesh.Indices, a, c, d)
+				}
+			}
+		}
+	}
+	return mesh
+}
+
+func (edge tessEdge) xAt(y float64) float64 {
+	if y <= edge.y0 {
+		return edge.x0
+	}
+	if y >= edge.y1 {
+		return edge.x1
+	}
+	return edge.x0 + (edge.x1-edge.x0)*(y-edge.y0)/(edge.y1-edge.y0)
+}
+
+func edgeIntersection(a, b tessEdge) (float64, bool) {
+	dxA, dyA := a.x1-a.x0, a.y1-a.y0
+	dxB, dyB := b.x1-b.x0, b.y1-b.y0
+	denominator := dxA*dyB - dyA*dxB
+	if math.Abs(denominator) < 1e-12 {
+		return 0, false
+	}
+	t := ((b.x0-a.x0)*dyB - (b.y0-a.y0)*dxB) / denominator
+	u := ((b.x0-a.x0)*dyA - (b.y0-a.y0)*dxA) / denominator
+	if t <= 0 || t >= 1 || u <= 0 || u >= 1 {
+		return 0, false
+	}
+	return a.y0 + t*dyA, true
+}
+
+func (path PathBuffer) fillMesh(rule FillRule) *tessMesh {
+	mesh, ok := path.fills[rule]
+	if ok && mesh.revision == path.revision {
+		return mesh
+	}
+	if !ok {
+		mesh = &tessMesh{}
+		gl.CreateVertexArrays(1, &mesh.vao)
+		gl.BindVertexArray(mesh.vao)
+
+		mesh.vbo = CreateBuffer()
+		mesh.vbo.Bind()
+		gl.EnableVertexAttribArray(0)
+		gl.VertexAttribPointerWithOffset(0, 2, gl.FLOAT, false, int32(unsafe.Sizeof(mgl32.Vec2{})), 0)
+
+		gl.CreateBuffers(1, &mesh.ibo)
+		gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, mesh.ibo)
+
+		if path.fills != nil {
+			path.fills[rule] = mesh
+		}
+	}
+
+	triangles := Tessellate(path.polylines, rule)
+	mesh.size = len(triangles.Indices)
+	mesh.revision = path.revision
+	if len(triangles.Indices) > 0 {
+		mesh.vbo.Bind()
+		gl.BufferData(gl.ARRAY_BUFFER, len(triangles.Vertices)*int(unsafe.Sizeof(mgl32.Vec2{})), unsafe.Pointer(&triangles.Vertices[0]), gl.DYNAMIC_DRAW)
+		gl.BindVertexArray(mesh.vao)
+		gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, mesh.ibo)
+		gl.BufferData(gl.ELEMENT_ARRAY_BUFFER, 4*len(triangles.Indices), unsafe.Pointer(&triangles.Indices[0]), gl.DYNAMIC_DRAW)
+	}
+	return mesh
+}
+
+func (mesh *tessMesh) draw() {
+	gl.BindVertexArray(mesh.vao)
+	gl.DrawElementsWithOffset(gl.TRIANGLES, int32(mesh.size), gl.UNSIGNED_INT, 0)
+}
+
+func (mesh *tessMesh) Delete() {
+	gl.DeleteVertexArrays(1, &mesh.vao)
+	mesh.vbo.Delete()
+	gl.DeleteBuffers(1, &mesh.ibo)
+}
@@ -0,0 +1,102 @@
+package graphics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func square(x, y, size float32, clockwise bool) Polyline {
+	points := []mgl32.Vec2{{x, y}, {x + size, y}, {x + size, y + size}, {x, y + size}}
+	if clockwise {
+		points[1], points[3] = points[3], points[1]
+	}
+	return Polyline{Points: points, Closed: true}
+}
+
+func pentagram(radius float64) Polyline {
+	polyline := Polyline{Closed: true}
+	for i := 0; i < 5; i++ {
+		angle := float64(i*2) * 2 * math.Pi / 5
+		polyline.Points = append(polyline.Points, mgl32.Vec2{float32(radius * math.Cos(angle)), float32(radius * math.Sin(angle))})
+	}
+	return polyline
+}
+
+func meshArea(t *testing.T, name string, mesh TriangleMesh) float64 {
+	if len(mesh.Indices)%3 != 0 {
+		t.Errorf("%s: %d indices is not a triangle list", name, len(mesh.Indices))
+	}
+	area := 0.0
+	for i := 0; i+2 < len(mesh.Indices); i += 3 {
+		triangle := cross2(mesh.Vertices[mesh.Indices[i]], mesh.Vertices[mesh.Indices[i+1]], mesh.Vertices[mesh.Indices[i+2]]) / 2
+		if triangle < -1e-6 {
+			t.Errorf("%s: triangle %d is wound clockwise", name, i/3)
+		}
+		area += triangle
+	}
+	return area
+}
+
+func TestTessellate(t *testing.T) {
+	r := 10 * math.Cos(2*math.Pi/5) / math.Cos(math.Pi/5)
+	star := 5 * 10 * r * math.Sin(math.Pi/5)
+	inner := 2.5 * r * r * math.Sin(2*math.Pi/5)
+
+	tests := []struct {
+		name      string
+		polylines []Polyline
+		rule      FillRule
+		area      float64
+	}{
+		{"square", []Polyline{square(0, 0, 10, false)}, FillNonZero, 100},
+		{"clockwise square", []Polyline{square(0, 0, 10, true)}, FillNonZero, 100},
+		{"concave", []Polyline{{Points: []mgl32.Vec2{{0, 0}, {10, 0}, {10, 10}, {5, 2}, {0, 10}}, Closed: true}}, FillNonZero, 60},
+		{"hole nonzero", []Polyline{square(0, 0, 10, false), square(2, 2, 6, false)}, FillNonZero, 100},
+		{"hole even-odd", []Polyline{square(0, 0, 10, false), square(2, 2, 6, false)}, FillEvenOdd, 64},
+		{"reversed hole nonzero", []Polyline{square(0, 0, 10, false), square(2, 2, 6, true)}, FillNonZero, 64},
+		{"reversed hole even-odd", []Polyline{square(0, 0, 10, false), square(2, 2, 6, true)}, FillEvenOdd, 64},
+		{"overlap nonzero", []Polyline{square(0, 0, 10, false), square(5, 5, 10, false)}, FillNonZero, 175},
+		{"overlap even-odd", []Polyline{square(0, 0, 10, false), square(5, 5, 10, false)}, FillEvenOdd, 150},
+		{"pentagram nonzero", []Polyline{pentagram(10)}, FillNonZero, star},
+		{"pentagram even-odd", []Polyline{pentagram(10)}, FillEvenOdd, star - inner},
+		{"degenerate", []Polyline{{Points: []mgl32.Vec2{{0, 0}, {10, 0}}}}, FillNonZero, 0},
+	}
+	for _, test := range tests {
+		area := meshArea(t, test.name, Tessellate(test.polylines, test.rule))
+		if math.Abs(area-test.area) > 1e-3 {
+			t.Errorf("%s: area %v, want %v", test.name, area, test.area)
+		}
+	}
+}
+
+func TestEarClipMatchesSweep(t *testing.T) {
+	polygons := [][]mgl32.Vec2{
+		square(0, 0, 10, false).Points,
+		{{0, 0}, {10, 0}, {10, 10}, {5, 2}, {0, 10}},
+		{{0, 0}, {4, 1}, {8, 0}, {7, 4}, {8, 8}, {4, 7}, {0, 8}, {1, 4}},
+	}
+	for i, polygon := range polygons {
+		if !isSimplePolygon(polygon) {
+			t.Errorf("polygon %d: expected a simple polygon", i)
+			continue
+		}
+		clipped, ok := earClip(polygon)
+		if !ok {
+			t.Errorf("polygon %d: ear clipping failed", i)
+			continue
+		}
+		if triangles := len(clipped.Indices) / 3; triangles != len(polygon)-2 {
+			t.Errorf("polygon %d: ear clipping made %d triangles, want %d", i, triangles, len(polygon)-2)
+		}
+		swept := sweepTessellate([][]mgl32.Vec2{polygon}, FillNonZero)
+		if a, b := meshArea(t, "ear clip", clipped), meshArea(t, "sweep", swept); math.Abs(a-b) > 1e-3 {
+			t.Errorf("polygon %d: ear clip area %v, sweep area %v", i, a, b)
+		}
+	}
+
+	if isSimplePolygon(pentagram(10).Points) {
+		t.Error("pentagram should not be simple")
+	}
+}
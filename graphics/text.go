@@ -3,6 +3,7 @@ package graphics
 import (
 	"encoding/json"
 	"log"
+	"sort"
 	"unsafe"
 
 	"github.com/go-gl/gl/v4.1-core/gl"
@@ -22,35 +23,101 @@ type TextRenderer struct {
 }
 
 type Font struct {
-	texture Texture
-	data    FontData
+	textures  []Texture
+	data      FontData
+	fallbacks []Font
+	cache     *glyphCache
 }
 
 type FontData struct {
-	Name          string
-	Size          int
-	Bold, Italic  bool
-	Width, Height int
-	Characters    map[string]Character
+	Name       string               `json:"name"`
+	Size       int                  `json:"size"`
+	Bold       bool                 `json:"bold"`
+	Italic     bool                 `json:"italic"`
+	Width      int                  `json:"width"`
+	Height     int                  `json:"height"`
+	LineHeight int                  `json:"lineHeight,omitempty"`
+	Base       int                  `json:"base,omitempty"`
+	Bitmap     bool                 `json:"bitmap,omitempty"`
+	Pages      []string             `json:"pages,omitempty"`
+	Characters map[string]Character `json:"characters"`
+	Kerning    map[string]int       `json:"kerning,omitempty"`
 }
 
 type Character struct {
-	X, Y             int
-	Width, Height    int
-	OriginX, OriginY int
-	Advance          int
+	X       int `json:"x"`
+	Y       int `json:"y"`
+	Width   int `json:"width"`
+	Height  int `json:"height"`
+	OriginX int `json:"originX"`
+	OriginY int `json:"originY"`
+	Advance int `json:"advance"`
+	Page    int `json:"page,omitempty"`
 }
 
 type Text struct {
-	texture       Texture
 	vao, vbo, ibo uint32
 	font          Font
+	icons         IconAtlas
+	runs          []TextRun
+	options       LayoutOptions
+	layout        TextLayout
+	pinned        []Texture
 	length        int
+	ranges        []textRange
+	glyphs        []glyphQuad
+	order         []int
+	effect        GlyphEffect
+	animated      bool
+	visible       int
+	time          float32
+}
+
+type textRange struct {
+	texture      Texture
+	mode         int
+	start, count int
+}
+
+const (
+	textModeSDF = iota
+	textModeBitmap
+	textModeImage
+)
+
+type glyphQuad struct {
+	min, max       mgl32.Vec2
+	texMin, texMax mgl32.Vec2
+	texture        Texture
+	mode           int
+	color          mgl32.Vec4
+	threshold      float32
+	shear          float32
+	baseline       float32
+	r              rune
+	source         int
+	effect         GlyphEffect
 }
 
 type TextVertex struct {
-	pos      mgl32.Vec2
-	texCoord mgl32.Vec2
+	pos       mgl32.Vec2
+	texCoord  mgl32.Vec2
+	color     mgl32.Vec4
+	threshold float32
+}
+
+type IconAtlas struct {
+	texture Texture
+	icons   map[string]Icon
+}
+
+type Icon struct {
+	X, Y          int
+	Width, Height int
+}
+
+func NewIconAtlas(texture Texture, icons map[string]Icon) IconAtlas {
+	return IconAtlas{texture, icons}
 }
 
 func LoadFont(textureData []byte, fontDataJSON []byte) (Font, error) {
@@ -63,7 +130,93 @@ func LoadFont(textureData []byte, fontDataJSON []byte) (Font, error) {
 	if err != nil {
 		return Font{}, err
 	}
-	return Font{texture, fontData}, nil
+	return Font{textures: []Texture{texture}, data: fontData}, nil
+}
+
+func NewFont(data FontData) Font {
+	return Font{data: data}
+}
+
+func (font Font) character(r rune) (Character, bool) {
+	if font.cache != nil {
+		return font.cache.character(r)
+	}
+	char, ok := font.data.Characters[string(r)]
+	return char, ok
+}
+
+func (font Font) texture(char Character) (Texture, bool) {
+	if font.cache != nil {
+		return font.cache.texture(char.Page)
+	}
+	if char.Page < 0 || char.Page >= len(font.textures) {
+		return Texture{}, false
+	}
+	return font.textures[char.Page], true
+}
+
+func (font Font) WithFallbacks(fallbacks ...Font) Font {
+	font.fallbacks = append(append([]Font{}, font.fallbacks...), fallbacks...)
+	return font
+}
+
+func (font Font) chain() []Font {
+	chain := []Font{font}
+	for _, fallback := range font.fallbacks {
+		chain = append(chain, fallback.chain()...)
+	}
+	return chain
+}
+
+func (font Font) HasRune(r rune) bool {
+	for _, f := range font.chain() {
+		if f.cache != nil && f.cache.ttf.Index(r) != 0 {
+			return true
+		}
+		if _, ok := f.data.Characters[string(r)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (font Font) kerning(prev, r rune) int {
+	if font.cache != nil {
+		return font.cache.kerning(prev, r)
+	}
+	return font.data.Kerning[string(prev)+string(r)]
+}
+
+func (font Font) beginLayout() {
+	for _, f := range font.chain() {
+		if f.cache != nil {
+			f.cache.beginPass()
+		}
+	}
+}
+
+func (font Font) pin(textures []Texture, delta int) {
+	for _, f := range font.chain() {
+		if f.cache != nil {
+			f.cache.pin(textures, delta)
+		}
+	}
+}
+
+func (font Font) LineHeight() int {
+	if font.data.LineHeight > 0 {
+		return font.data.LineHeight
+	}
+	return font.data.Size
+}
+
+func (font Font) Delete() {
+	for _, texture := range font.textures {
+		texture.Delete()
+	}
+	if font.cache != nil {
+		font.cache.Delete()
+	}
 }
 
 func CreateTextRenderer() TextRenderer {
@@ -84,7 +237,7 @@ func CreateTextRenderer() TextRenderer {
 }
 
 func CreateText(str string, font Font) Text {
-	text := Text{font: font}
+	text := Text{font: font, options: DefaultLayoutOptions, visible: -1}
 
 	gl.CreateVertexArrays(1, &text.vao)
 	gl.BindVertexArray(text.vao)
@@ -95,6 +248,10 @@ func CreateText(str string, font Font) Text {
 	gl.VertexAttribPointerWithOffset(0, 2, gl.FLOAT, false, int32(unsafe.Sizeof(TextVertex{})), unsafe.Offsetof(TextVertex{}.pos))
 	gl.EnableVertexAttribArray(1)
 	gl.VertexAttribPointerWithOffset(1, 2, gl.FLOAT, false, int32(unsafe.Sizeof(TextVertex{})), unsafe.Offsetof(TextVertex{}.texCoord))
+	gl.EnableVertexAttribArray(2)
+	gl.VertexAttribPointerWithOffset(2, 4, gl.FLOAT, false, int32(unsafe.Sizeof(TextVertex{})), unsafe.Offsetof(TextVertex{}.color))
+	gl.EnableVertexAttribArray(3)
+	gl.VertexAttribPointerWithOffset(3, 1, gl.FLOAT, false, int32(unsafe.Sizeof(TextVertex{})), unsafe.Offsetof(TextVertex{}.threshold))
 
 	gl.CreateBuffers(1, &text.ibo)
 	gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, text.ibo)
@@ -104,31 +261,90 @@ func CreateText(str string, font Font) Text {
 	return text
 }
 
+func CreateRichText(markup string, font Font, icons IconAtlas) (Text, error) {
+	text := CreateText("", font)
+	text.icons = icons
+	err := text.SetMarkup(markup)
+	return text, err
+}
+
+func (quad glyphQuad) vertices(offset mgl32.Vec2, color mgl32.Vec4) [4]TextVertex {
+	vertex := func(x, y, tx, ty float32) TextVertex {
+		x += (y-quad.baseline)*quad.shear + offset.X()
+		y += offset.Y()
+		return TextVertex{mgl32.Vec2{x, y}, mgl32.Vec2{tx, ty}, color, quad.threshold}
+	}
+	return [4]TextVertex{
+		vertex(quad.max.X(), quad.max.Y(), quad.texMax.X(), quad.texMax.Y()),
+		vertex(quad.max.X(), quad.min.Y(), quad.texMax.X(), quad.texMin.Y()),
+		vertex(quad.min.X(), quad.min.Y(), quad.texMin.X(), quad.texMin.Y()),
+		vertex(quad.min.X(), quad.max.Y(), quad.texMin.X(), quad.texMax.Y()),
+	}
+}
+
 func (text *Text) SetString(str string) {
-	vertices := make([]TextVertex, len(str)*4)
-	indicies := make([]uint32, len(str)*6)
+	text.SetRuns([]TextRun{{Text: str, Style: DefaultTextStyle}})
+}
 
-	left := float32(0)
+func (text *Text) SetMarkup(markup string) error {
+	runs, err := ParseMarkup(markup)
+	if err != nil {
+		return err
+	}
+	return text.SetRuns(runs)
+}
 
-	for i := range str {
+func (text *Text) SetRuns(runs []TextRun) error {
+	layout, quads, err := layoutText(text.font, text.icons, runs, text.options)
+	if err != nil {
+		return err
+	}
+	text.runs = runs
+	text.layout = layout
+	text.setQuads(quads)
+	pinned := []Texture{}
+	for _, r := range text.ranges {
+		pinned = append(pinned, r.texture)
+	}
+	text.font.pin(pinned, 1)
+	text.font.pin(text.pinned, -1)
+	text.pinned = pinned
+	return nil
+}
 
-		charData := text.font.data.Characters[str[i:i+1]]
+func (text *Text) SetLayoutOptions(options LayoutOptions) {
+	text.options = options
+	text.SetRuns(text.runs)
+}
 
-		right := left + float32(charData.Width)
-		bottom := float32(0.0)
-		top := float32(charData.Height)
+func (text *Text) Layout() TextLayout {
+	return text.layout
+}
 
-		leftTex := float32(charData.X) / float32(text.font.texture.width)
-		rightTex := float32(charData.X+charData.Width) / float32(text.font.texture.width)
-		topTex := float32(charData.Y) / float32(text.font.texture.height)
-		bottomTex := float32(charData.Y+charData.Height) / float32(text.font.texture.height)
+func (text *Text) setQuads(quads []glyphQuad) {
+	text.glyphs = quads
+	text.animated = false
+	for _, quad := range quads {
+		text.animated = text.animated || quad.effect != nil
+	}
+	text.order = make([]int, len(quads))
+	for i := range text.order {
+		text.order[i] = i
+	}
+	sort.SliceStable(text.order, func(i, j int) bool {
+		a, b := quads[text.order[i]], quads[text.order[j]]
+		if a.texture.textureID != b.texture.textureID {
+			return a.texture.textureID < b.texture.textureID
+		}
+		return a.mode < b.mode
+	})
 
-		copy(vertices[i*4:(i+1)*4], []TextVertex{
-			{mgl32.Vec2{right, top}, mgl32.Vec2{rightTex, topTex}},
-			{mgl32.Vec2{right, bottom}, mgl32.Vec2{rightTex, bottomTex}},
-			{mgl32.Vec2{left, bottom}, mgl32.Vec2{leftTex, bottomTex}},
-			{mgl32.Vec2{left, top}, mgl32.Vec2{leftTex, topTex}},
-		})
+	indicies := make([]uint32, len(quads)*6)
+
+	text.ranges = text.ranges[:0]
+
+	for i, glyph := range text.order {
+		quad := quads[glyph]
 
 		j := uint32(i * 4)
 		copy(indicies[i*6:(i+1)*6], []uint32{
@@ -136,24 +352,75 @@ func (text *Text) SetString(str string) {
 			j + 0, j + 2, j + 3,
 		})
 
-		left += float32(charData.Advance)
+		last := len(text.ranges) - 1
+		if last >= 0 && text.ranges[last].texture == quad.texture && text.ranges[last].mode == quad.mode {
+			text.ranges[last].count += 6
+		} else {
+			text.ranges = append(text.ranges, textRange{quad.texture, quad.mode, i * 6, 6})
+		}
 	}
 
-	text.length = len(str)
+	text.length = len(quads)
+
+	if len(quads) == 0 {
+		return
+	}
+
+	vertices := text.vertices()
 
 	gl.BindBuffer(gl.ARRAY_BUFFER, text.vbo)
-	gl.BufferData(gl.ARRAY_BUFFER, int(unsafe.Sizeof(TextVertex{}))*len(vertices), unsafe.Pointer(&vertices[0]), gl.STATIC_DRAW)
+	gl.BufferData(gl.ARRAY_BUFFER, int(unsafe.Sizeof(TextVertex{}))*len(vertices), unsafe.Pointer(&vertices[0]), gl.DYNAMIC_DRAW)
 
 	gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, text.ibo)
 	gl.BufferData(gl.ELEMENT_ARRAY_BUFFER, 4*len(indicies), unsafe.Pointer(&indicies[0]), gl.STATIC_DRAW)
 }
 
-func (renderer *TextRenderer) Render(text Text, transform mgl32.Mat3) {
+func (text *Text) vertices() []TextVertex {
+	vertices := make([]TextVertex, len(text.order)*4)
+	for i, glyph := range text.order {
+		quad := text.glyphs[glyph]
+		state := GlyphState{Index: glyph, Rune: quad.r, Color: quad.color}
+		if quad.effect != nil {
+			quad.effect(&state, text.time)
+		}
+		if text.effect != nil {
+			text.effect(&state, text.time)
+		}
+		if text.visible >= 0 && quad.source >= text.visible {
+			state.Color[3] = 0
+		}
+		quadVertices := quad.vertices(state.Offset, state.Color)
+		copy(vertices[i*4:(i+1)*4], quadVertices[:])
+	}
+	return vertices
+}
+
+func (text *Text) refresh() {
+	if text.length == 0 {
+		return
+	}
+	vertices := text.vertices()
+	gl.BindBuffer(gl.ARRAY_BUFFER, text.vbo)
+	gl.BufferSubData(gl.ARRAY_BUFFER, 0, int(unsafe.Sizeof(TextVertex{}))*len(vertices), unsafe.Pointer(&vertices[0]))
+}
+
+func (renderer *TextRenderer) Render(text *Text, transform mgl32.Mat3) {
 	gl.BindVertexArray(text.vao)
-	renderer.program.Bind(map[string]Uniform{
-		"textureSampler": 0,
-		"transform":      transform,
-	})
-	text.font.texture.Bind(0)
-	gl.DrawElementsWithOffset(gl.TRIANGLES, int32(text.length)*6, gl.UNSIGNED_INT, 0)
+	for _, r := range text.ranges {
+		renderer.program.Bind(map[string]Uniform{
+			"textureSampler": 0,
+			"transform":      transform,
+			"mode":           r.mode,
+		})
+		r.texture.Bind(0)
+		gl.DrawElementsWithOffset(gl.TRIANGLES, int32(r.count), gl.UNSIGNED_INT, uintptr(r.start*4))
+	}
+}
+
+func (text *Text) Delete() {
+	text.font.pin(text.pinned, -1)
+	text.pinned = nil
+	gl.DeleteVertexArrays(1, &text.vao)
+	gl.DeleteBuffers(1, &text.vbo)
+	gl.DeleteBuffers(1, &text.ibo)
 }
@@ -0,0 +1,160 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+type GlyphState struct {
+	Index  int
+	Rune   rune
+	Offset mgl32.Vec2
+	Color  mgl32.Vec4
+}
+
+type GlyphEffect func(glyph *GlyphState, time float32)
+
+type Typewriter struct {
+	text     *Text
+	speed    float32
+	onReveal func(index int, r rune)
+	elapsed  float32
+	revealed int
+}
+
+func (text *Text) SetEffect(effect GlyphEffect) {
+	text.effect = effect
+	text.refresh()
+}
+
+func (text *Text) SetVisible(count int) {
+	if count == text.visible {
+		return
+	}
+	text.visible = count
+	text.refresh()
+}
+
+func (text *Text) GlyphCount() int {
+	return len(text.glyphs)
+}
+
+func (text *Text) CharacterCount() int {
+	return len(text.layout.Glyphs)
+}
+
+func (text *Text) Update(time float32) {
+	text.time = time
+	if text.effect != nil || text.animated {
+		text.refresh()
+	}
+}
+
+func CombineEffects(effects ...GlyphEffect) GlyphEffect {
+	return func(glyph *GlyphState, time float32) {
+		for _, effect := range effects {
+			effect(glyph, time)
+		}
+	}
+}
+
+func WaveEffect(amplitude, frequency, speed float32) GlyphEffect {
+	return func(glyph *GlyphState, time float32) {
+		phase := float64(float32(glyph.Index)*frequency + time*speed)
+		glyph.Offset[1] += amplitude * float32(math.Sin(phase))
+	}
+}
+
+func ShakeEffect(amplitude, speed float32) GlyphEffect {
+	return func(glyph *GlyphState, time float32) {
+		step := float32(math.Floor(float64(time * speed)))
+		glyph.Offset[0] += amplitude * (2*hashNoise(float32(glyph.Index), step) - 1)
+		glyph.Offset[1] += amplitude * (2*hashNoise(step, float32(glyph.Index)) - 1)
+	}
+}
+
+func RainbowEffect(speed, spread float32) GlyphEffect {
+	return func(glyph *GlyphState, time float32) {
+		hue := float64(time*speed + float32(glyph.Index)*spread)
+		hue -= math.Floor(hue)
+		r, g, b := hsvToRGB(float32(hue), 1, 1)
+		glyph.Color = mgl32.Vec4{r, g, b, glyph.Color.W()}
+	}
+}
+
+func hashNoise(x, y float32) float32 {
+	n := math.Sin(float64(x)*12.9898+float64(y)*78.233) * 43758.5453
+	return float32(n - math.Floor(n))
+}
+
+func hsvToRGB(h, s, v float32) (float32, float32, float32) {
+	i := int(h * 6)
+	f := h*6 - float32(i)
+	p := v * (1 - s)
+	q := v * (1 - f*s)
+	t := v * (1 - (1-f)*s)
+	switch i % 6 {
+	case 0:
+		return v, t, p
+	case 1:
+		return q, v, p
+	case 2:
+		return p, v, t
+	case 3:
+		return p, q, v
+	case 4:
+		return t, p, v
+	default:
+		return v, p, q
+	}
+}
+
+func NewTypewriter(text *Text, speed float32, onReveal func(index int, r rune)) *Typewriter {
+	text.SetVisible(0)
+	return &Typewriter{text: text, speed: speed, onReveal: onReveal}
+}
+
+func (typewriter *Typewriter) Update(dt float32) {
+	if typewriter.Done() {
+		return
+	}
+	typewriter.elapsed += dt
+	target := int(typewriter.elapsed * typewriter.speed)
+	typewriter.revealTo(target, true)
+}
+
+func (typewriter *Typewriter) revealTo(target int, notify bool) {
+	count := typewriter.text.CharacterCount()
+	if target > count {
+		target = count
+	}
+	if target <= typewriter.revealed {
+		return
+	}
+	for i := typewriter.revealed; i < target; i++ {
+		if notify && typewriter.onReveal != nil {
+			typewriter.onReveal(i, typewriter.text.layout.Glyphs[i].Rune)
+		}
+	}
+	typewriter.revealed = target
+	if target == count {
+		typewriter.text.SetVisible(-1)
+	} else {
+		typewriter.text.SetVisible(target)
+	}
+}
+
+func (typewriter *Typewriter) Skip() {
+	typewriter.revealTo(typewriter.text.CharacterCount(), false)
+}
+
+func (typewriter *Typewriter) Done() bool {
+	return typewriter.revealed >= typewriter.text.CharacterCount()
+}
+
+func (typewriter *Typewriter) Restart() {
+	typewriter.elapsed = 0
+	typewriter.revealed = 0
+	typewriter.text.SetVisible(0)
+}
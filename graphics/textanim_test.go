@@ -0,0 +1,61 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func testText(t *testing.T, str string) *Text {
+	layout, quads, err := layoutText(NewFont(testFontData()), IconAtlas{}, []TextRun{{Text: str, Style: DefaultTextStyle}}, LayoutOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Text{layout: layout, glyphs: quads, visible: -1}
+}
+
+func TestTypewriterRevealsEveryCharacter(t *testing.T) {
+	text := testText(t, "a b!c")
+	revealed := []rune{}
+	indices := []int{}
+	typewriter := NewTypewriter(text, 10, func(index int, r rune) {
+		indices = append(indices, index)
+		revealed = append(revealed, r)
+	})
+
+	typewriter.Update(0.25)
+	if string(revealed) != "a " || text.visible != 2 {
+		t.Errorf("got %q with %d visible, want \"a \" with 2", string(revealed), text.visible)
+	}
+	typewriter.Update(0.02)
+	if len(revealed) != 2 {
+		t.Errorf("revealed %d characters before the next step, want 2", len(revealed))
+	}
+	typewriter.Update(1)
+	if string(revealed) != "a b!c" || !typewriter.Done() || text.visible != -1 {
+		t.Errorf("got %q, done %v, visible %d", string(revealed), typewriter.Done(), text.visible)
+	}
+	for i, index := range indices {
+		if index != i {
+			t.Errorf("callback %d reported index %d", i, index)
+		}
+	}
+
+	typewriter.Restart()
+	typewriter.Skip()
+	if len(revealed) != 5 || !typewriter.Done() {
+		t.Errorf("Skip notified %d extra characters", len(revealed)-5)
+	}
+}
+
+func TestTextVisibleHidesBySourceCharacter(t *testing.T) {
+	text := &Text{
+		glyphs:  []glyphQuad{{source: 0, color: mgl32.Vec4{1, 1, 1, 1}}, {source: 2, color: mgl32.Vec4{1, 1, 1, 1}}},
+		order:   []int{0, 1},
+		visible: 2,
+	}
+	vertices := text.vertices()
+	if vertices[0].color.W() != 1 || vertices[4].color.W() != 0 {
+		t.Errorf("got alphas %v and %v, want 1 and 0", vertices[0].color.W(), vertices[4].color.W())
+	}
+}
@@ -0,0 +1,186 @@
+package graphics
+
+import (
+	"unsafe"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+type TextBatch struct {
+	vao, vbo, ibo  uint32
+	vertexCapacity int
+	indexCapacity  int
+	groups         []textBatchGroup
+	lookup         map[textBatchKey]int
+	vertices       []TextVertex
+	pins           []textBatchPin
+}
+
+type textBatchPin struct {
+	font     Font
+	textures []Texture
+}
+
+type textBatchKey struct {
+	textureID uint32
+	mode      int
+}
+
+type textBatchGroup struct {
+	texture  Texture
+	mode     int
+	vertices []TextVertex
+}
+
+func CreateTextBatch() *TextBatch {
+	batch := &TextBatch{lookup: make(map[textBatchKey]int)}
+
+	gl.CreateVertexArrays(1, &batch.vao)
+	gl.BindVertexArray(batch.vao)
+
+	gl.CreateBuffers(1, &batch.vbo)
+	gl.BindBuffer(gl.ARRAY_BUFFER, batch.vbo)
+	gl.EnableVertexAttribArray(0)
+	gl.VertexAttribPointerWithOffset(0, 2, gl.FLOAT, false, int32(unsafe.Sizeof(TextVertex{})), unsafe.Offsetof(TextVertex{}.pos))
+	gl.EnableVertexAttribArray(1)
+	gl.VertexAttribPointerWithOffset(1, 2, gl.FLOAT, false, int32(unsafe.Sizeof(TextVertex{})), unsafe.Offsetof(TextVertex{}.texCoord))
+	gl.EnableVertexAttribArray(2)
+	gl.VertexAttribPointerWithOffset(2, 4, gl.FLOAT, false, int32(unsafe.Sizeof(TextVertex{})), unsafe.Offsetof(TextVertex{}.color))
+	gl.EnableVertexAttribArray(3)
+	gl.VertexAttribPointerWithOffset(3, 1, gl.FLOAT, false, int32(unsafe.Sizeof(TextVertex{})), unsafe.Offsetof(TextVertex{}.threshold))
+
+	gl.CreateBuffers(1, &batch.ibo)
+	gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, batch.ibo)
+
+	return batch
+}
+
+func (batch *TextBatch) Add(font Font, str string, transform mgl32.Mat3, color mgl32.Vec4) error {
+	style := DefaultTextStyle
+	style.Color = color
+	return batch.AddRuns(font, IconAtlas{}, []TextRun{{Text: str, Style: style}}, DefaultLayoutOptions, transform)
+}
+
+func (batch *TextBatch) AddRuns(font Font, icons IconAtlas, runs []TextRun, options LayoutOptions, transform mgl32.Mat3) error {
+	_, quads, err := layoutText(font, icons, runs, options)
+	if err != nil {
+		return err
+	}
+
+	pin := textBatchPin{font: font}
+	for _, quad := range quads {
+		if len(pin.textures) == 0 || pin.textures[len(pin.textures)-1] != quad.texture {
+			pin.textures = append(pin.textures, quad.texture)
+		}
+	}
+	font.pin(pin.textures, 1)
+	batch.pins = append(batch.pins, pin)
+
+	for _, quad := range quads {
+		key := textBatchKey{quad.texture.textureID, quad.mode}
+		i, ok := batch.lookup[key]
+		if !ok {
+			i = len(batch.groups)
+			batch.lookup[key] = i
+			batch.groups = append(batch.groups, textBatchGroup{texture: quad.texture, mode: quad.mode})
+		}
+		group := &batch.groups[i]
+
+		vertices := quad.vertices(mgl32.Vec2{0, 0}, quad.color)
+		for _, vertex := range vertices {
+			vertex.pos = transform.Mul3x1(vertex.pos.Vec3(1)).Vec2()
+			group.vertices = append(group.vertices, vertex)
+		}
+	}
+
+	return nil
+}
+
+func (batch *TextBatch) Clear() {
+	batch.unpin()
+	groups := batch.groups[:0]
+	batch.lookup = make(map[textBatchKey]int)
+	for _, group := range batch.groups {
+		if len(group.vertices) == 0 {
+			continue
+		}
+		group.vertices = group.vertices[:0]
+		batch.lookup[textBatchKey{group.texture.textureID, group.mode}] = len(groups)
+		groups = append(groups, group)
+	}
+	for i := len(groups); i < len(batch.groups); i++ {
+		batch.groups[i] = textBatchGroup{}
+	}
+	batch.groups = groups
+}
+
+func (batch *TextBatch) unpin() {
+	for _, pin := range batch.pins {
+		pin.font.pin(pin.textures, -1)
+	}
+	batch.pins = batch.pins[:0]
+}
+
+func (batch *TextBatch) upload() {
+	batch.vertices = batch.vertices[:0]
+	for _, group := range batch.groups {
+		batch.vertices = append(batch.vertices, group.vertices...)
+	}
+
+	if len(batch.vertices) == 0 {
+		return
+	}
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, batch.vbo)
+	if len(batch.vertices) > batch.vertexCapacity {
+		batch.vertexCapacity = len(batch.vertices) * 2
+		gl.BufferData(gl.ARRAY_BUFFER, batch.vertexCapacity*int(unsafe.Sizeof(TextVertex{})), nil, gl.DYNAMIC_DRAW)
+	}
+	gl.BufferSubData(gl.ARRAY_BUFFER, 0, len(batch.vertices)*int(unsafe.Sizeof(TextVertex{})), unsafe.Pointer(&batch.vertices[0]))
+
+	quads := len(batch.vertices) / 4
+	if quads*6 > batch.indexCapacity {
+		batch.indexCapacity = batch.vertexCapacity / 4 * 6
+		indicies := make([]uint32, batch.indexCapacity)
+		for i := 0; i < batch.indexCapacity/6; i++ {
+			j := uint32(i * 4)
+			copy(indicies[i*6:(i+1)*6], []uint32{
+				j + 0, j + 1, j + 2,
+				j + 0, j + 2, j + 3,
+			})
+		}
+		gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, batch.ibo)
+		gl.BufferData(gl.ELEMENT_ARRAY_BUFFER, 4*len(indicies), unsafe.Pointer(&indicies[0]), gl.STATIC_DRAW)
+	}
+}
+
+func (renderer *TextRenderer) RenderBatch(batch *TextBatch, transform mgl32.Mat3) {
+	gl.BindVertexArray(batch.vao)
+	batch.upload()
+
+	start := 0
+	for _, group := range batch.groups {
+		count := len(group.vertices) / 4 * 6
+		if count == 0 {
+			continue
+		}
+		renderer.program.Bind(map[string]Uniform{
+			"textureSampler": 0,
+			"transform":      transform,
+			"mode":           group.mode,
+		})
+		group.texture.Bind(0)
+		gl.DrawElementsWithOffset(gl.TRIANGLES, int32(count), gl.UNSIGNED_INT, uintptr(start*4))
+		start += count
+	}
+
+	batch.Clear()
+}
+
+func (batch *TextBatch) Delete() {
+	batch.unpin()
+	gl.DeleteVertexArrays(1, &batch.vao)
+	gl.DeleteBuffers(1, &batch.vbo)
+	gl.DeleteBuffers(1, &batch.ibo)
+}
@@ -0,0 +1,63 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestTextBatchClearPrunesUnusedGroups(t *testing.T) {
+	first := Font{textures: []Texture{{textureID: 1, width: 64, height: 64}}, data: testFontData()}
+	second := Font{textures: []Texture{{textureID: 2, width: 64, height: 64}}, data: testFontData()}
+	batch := &TextBatch{lookup: make(map[textBatchKey]int)}
+
+	if err := batch.Add(first, "ab", mgl32.Ident3(), mgl32.Vec4{1, 1, 1, 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Add(second, "cd", mgl32.Ident3(), mgl32.Vec4{1, 1, 1, 1}); err != nil {
+		t.Fatal(err)
+	}
+	if len(batch.groups) != 2 || len(batch.groups[0].vertices) != 8 {
+		t.Fatalf("got %d groups, want 2 with 8 vertices in the first", len(batch.groups))
+	}
+
+	batch.Clear()
+	if len(batch.groups) != 2 || len(batch.groups[0].vertices) != 0 {
+		t.Fatalf("got %d groups after the first clear, want 2 empty groups", len(batch.groups))
+	}
+
+	batch.Add(second, "ef", mgl32.Ident3(), mgl32.Vec4{1, 1, 1, 1})
+	batch.Clear()
+	if len(batch.groups) != 1 || len(batch.lookup) != 1 || batch.groups[0].texture.textureID != 2 {
+		t.Fatalf("got %d groups and %d keys, want only the group for texture 2", len(batch.groups), len(batch.lookup))
+	}
+	if i, ok := batch.lookup[textBatchKey{2, textModeSDF}]; !ok || i != 0 {
+		t.Errorf("lookup points at %d, want 0", i)
+	}
+}
+
+func TestTextBatchAddReturnsLayoutErrors(t *testing.T) {
+	batch := &TextBatch{lookup: make(map[textBatchKey]int)}
+	runs := []TextRun{{Icon: "missing", Style: DefaultTextStyle}}
+	if err := batch.AddRuns(NewFont(testFontData()), IconAtlas{}, runs, LayoutOptions{}, mgl32.Ident3()); err == nil {
+		t.Error("expected an error for an unknown icon")
+	}
+}
+
+func TestTextBatchPinsPagesUntilClear(t *testing.T) {
+	texture := Texture{textureID: 3, width: 64, height: 64}
+	cache := &glyphCache{pages: []*glyphPage{{texture: texture}}}
+	font := Font{textures: []Texture{texture}, data: testFontData()}.WithFallbacks(Font{cache: cache})
+	batch := &TextBatch{lookup: make(map[textBatchKey]int)}
+
+	batch.Add(font, "ab", mgl32.Ident3(), mgl32.Vec4{1, 1, 1, 1})
+	batch.Add(font, "cd", mgl32.Ident3(), mgl32.Vec4{1, 1, 1, 1})
+	if cache.pages[0].pins != 2 || cache.victim() != -1 {
+		t.Errorf("got %d pins and victim %d, want 2 pins and no victim", cache.pages[0].pins, cache.victim())
+	}
+
+	batch.Clear()
+	if cache.pages[0].pins != 0 || cache.victim() != 0 {
+		t.Errorf("got %d pins and victim %d after Clear, want 0 pins and victim 0", cache.pages[0].pins, cache.victim())
+	}
+}
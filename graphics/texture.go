@@ -2,8 +2,8 @@ package graphics
 
 import (
 	"bytes"
-	"fmt"
 	"image"
+	"image/draw"
 	"unsafe"
 
 	_ "image/png"
@@ -43,12 +43,22 @@ func TextureFromPNG(data []byte) (Texture, error) {
 		return Texture{}, err
 	}
 
+	return TextureFromImage(img), nil
+}
+
+func TextureFromImage(img image.Image) Texture {
 	rgba, ok := img.(*image.NRGBA)
-	if !ok {
-		return Texture{}, fmt.Errorf("image must be a png image")
+	if !ok || rgba.Rect.Min != (image.Point{}) {
+		rgba = image.NewNRGBA(image.Rect(0, 0, img.Bounds().Dx(), img.Bounds().Dy()))
+		draw.Draw(rgba, rgba.Rect, img, img.Bounds().Min, draw.Src)
 	}
+	return TextureFromRGBA(rgba)
+}
 
-	return TextureFromRGBA(rgba), nil
+func (texture Texture) SetFilter(filter int32) {
+	gl.BindTexture(gl.TEXTURE_2D, texture.textureID)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, filter)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, filter)
 }
 
 func (texture Texture) Bind(n uint32) {
@@ -0,0 +1,99 @@
+package graphics
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/math/fixed"
+)
+
+type VectorFont struct {
+	font   *truetype.Font
+	scale  fixed.Int26_6
+	glyphs map[truetype.Index]*PathBuffer
+}
+
+type VectorText struct {
+	font    *VectorFont
+	glyphs  []vectorGlyph
+	advance float32
+}
+
+type vectorGlyph struct {
+	buffer *PathBuffer
+	offset mgl32.Vec2
+}
+
+func CreateVectorFont(f *truetype.Font) *VectorFont {
+	return &VectorFont{f, fixed.Int26_6(f.FUnitsPerEm()), make(map[truetype.Index]*PathBuffer)}
+}
+
+func (font *VectorFont) glyph(i truetype.Index) *PathBuffer {
+	if buffer, ok := font.glyphs[i]; ok {
+		return buffer
+	}
+	var buffer *PathBuffer
+	path := generateGlyphPath(font.font, i, font.scale, float32(font.scale))
+	if !path.Empty() {
+		pathBuffer := path.ToBuffer()
+		buffer = &pathBuffer
+	}
+	font.glyphs[i] = buffer
+	return buffer
+}
+
+func (font *VectorFont) Delete() {
+	for _, buffer := range font.glyphs {
+		if buffer != nil {
+			buffer.Delete()
+		}
+	}
+	font.glyphs = make(map[truetype.Index]*PathBuffer)
+}
+
+func CreateVectorText(str string, font *VectorFont) *VectorText {
+	text := &VectorText{font: font}
+	text.SetString(str)
+	return text
+}
+
+func (text *VectorText) SetString(str string) {
+	text.glyphs = text.glyphs[:0]
+
+	f := text.font.font
+	unitsPerEm := float32(text.font.scale)
+
+	left := float32(0)
+	hasPrev := false
+	prev := truetype.Index(0)
+
+	for _, r := range str {
+		i := f.Index(r)
+		if hasPrev {
+			left += float32(f.Kern(text.font.scale, prev, i)) / unitsPerEm
+		}
+		if buffer := text.font.glyph(i); buffer != nil {
+			text.glyphs = append(text.glyphs, vectorGlyph{buffer, mgl32.Vec2{left, 0}})
+		}
+		left += float32(f.HMetric(text.font.scale, i).AdvanceWidth) / unitsPerEm
+		prev = i
+		hasPrev = true
+	}
+
+	text.advance = left
+}
+
+func (text *VectorText) Advance() float32 {
+	return text.advance
+}
+
+func (text *VectorText) Fill(renderer *PathRenderer, transform mgl32.Mat3, paint Paint, style FillStyle) {
+	for _, glyph := range text.glyphs {
+		renderer.Fill(*glyph.buffer, transform.Mul3(mgl32.Translate2D(glyph.offset.X(), glyph.offset.Y())), paint, style)
+	}
+}
+
+func (text *VectorText) Stroke(renderer *PathRenderer, transform mgl32.Mat3, paint Paint, style StrokeStyle) {
+	for _, glyph := range text.glyphs {
+		renderer.Stroke(*glyph.buffer, transform.Mul3(mgl32.Translate2D(glyph.offset.X(), glyph.offset.Y())), paint, style)
+	}
+}
@@ -0,0 +1,325 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+type Args map[string]interface{}
+
+type Message struct {
+	Forms   map[Category]string
+	plural  pluralExpr
+	indexed []string
+}
+
+type Table map[string]Message
+
+type Localizable interface {
+	SetString(str string)
+}
+
+type MissingKey struct {
+	Locale string
+	Key    string
+}
+
+type binding struct {
+	target Localizable
+	key    string
+	count  int
+	plural bool
+	args   Args
+}
+
+type Bundle struct {
+	defaultLocale string
+	locale        string
+	tables        map[string]Table
+	bindings      []*binding
+	missing       map[MissingKey]bool
+	OnMissing     func(locale, key string)
+}
+
+func NewBundle(defaultLocale string) *Bundle {
+	return &Bundle{
+		defaultLocale: defaultLocale,
+		locale:        defaultLocale,
+		tables:        make(map[string]Table),
+		missing:       make(map[MissingKey]bool),
+	}
+}
+
+func (bundle *Bundle) Locale() string {
+	return bundle.locale
+}
+
+func (bundle *Bundle) Locales() []string {
+	locales := make([]string, 0, len(bundle.tables))
+	for locale := range bundle.tables {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+func (bundle *Bundle) AddTable(locale string, table Table) {
+	existing, ok := bundle.tables[locale]
+	if !ok {
+		bundle.tables[locale] = table
+		return
+	}
+	for key, message := range table {
+		existing[key] = message
+	}
+}
+
+func (bundle *Bundle) LoadJSON(locale string, data []byte) error {
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("i18n %s: %v", locale, err)
+	}
+	table := Table{}
+	for key, value := range raw {
+		str := ""
+		if err := json.Unmarshal(value, &str); err == nil {
+			table[key] = Message{Forms: map[Category]string{Other: str}}
+			continue
+		}
+		forms := map[string]string{}
+		if err := json.Unmarshal(value, &forms); err != nil {
+			return fmt.Errorf("i18n %s: key '%s' must be a string or an object of plural forms", locale, key)
+		}
+		message := Message{Forms: map[Category]string{}}
+		for name, form := range forms {
+			category, ok := ParseCategory(name)
+			if !ok {
+				return fmt.Errorf("i18n %s: key '%s' has unknown plural category '%s'", locale, key, name)
+			}
+			message.Forms[category] = form
+		}
+		if _, ok := message.Forms[Other]; !ok {
+			return fmt.Errorf("i18n %s: key '%s' is missing the 'other' plural form", locale, key)
+		}
+		table[key] = message
+	}
+	bundle.AddTable(locale, table)
+	return nil
+}
+
+func (bundle *Bundle) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		ext := path.Ext(name)
+		locale := strings.TrimSuffix(name, ext)
+		if ext != ".json" && ext != ".po" {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		if ext == ".json" {
+			err = bundle.LoadJSON(locale, data)
+		} else {
+			err = bundle.LoadPO(locale, data)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (bundle *Bundle) SetLocale(locale string) {
+	bundle.locale = locale
+	for _, binding := range bundle.bindings {
+		bundle.apply(binding)
+	}
+}
+
+func (bundle *Bundle) lookup(key string) (string, Message, bool) {
+	for _, locale := range fallbackLocales(bundle.locale, bundle.defaultLocale) {
+		if message, ok := bundle.tables[locale][key]; ok {
+			if locale != bundle.locale && locale != language(bundle.locale) {
+				bundle.reportMissing(bundle.locale, key)
+			}
+			return locale, message, true
+		}
+	}
+	bundle.reportMissing(bundle.locale, key)
+	return "", Message{}, false
+}
+
+func fallbackLocales(locale, defaultLocale string) []string {
+	locales := []string{locale}
+	if language := language(locale); language != locale {
+		locales = append(locales, language)
+	}
+	if defaultLocale != locale {
+		locales = append(locales, defaultLocale)
+	}
+	return locales
+}
+
+func language(locale string) string {
+	if i := strings.IndexAny(locale, "-_"); i >= 0 {
+		return locale[:i]
+	}
+	return locale
+}
+
+func (bundle *Bundle) reportMissing(locale, key string) {
+	missing := MissingKey{locale, key}
+	if bundle.missing[missing] {
+		return
+	}
+	bundle.missing[missing] = true
+	if bundle.OnMissing != nil {
+		bundle.OnMissing(locale, key)
+	}
+}
+
+func (bundle *Bundle) Missing() []MissingKey {
+	missing := make([]MissingKey, 0, len(bundle.missing))
+	for key := range bundle.missing {
+		missing = append(missing, key)
+	}
+	sortMissing(missing)
+	return missing
+}
+
+func (bundle *Bundle) MissingKeys(locale string) []MissingKey {
+	missing := []MissingKey{}
+	for key := range bundle.tables[bundle.defaultLocale] {
+		if _, ok := bundle.tables[locale][key]; !ok {
+			missing = append(missing, MissingKey{locale, key})
+		}
+	}
+	sortMissing(missing)
+	return missing
+}
+
+func sortMissing(missing []MissingKey) {
+	sort.Slice(missing, func(i, j int) bool {
+		if missing[i].Locale != missing[j].Locale {
+			return missing[i].Locale < missing[j].Locale
+		}
+		return missing[i].Key < missing[j].Key
+	})
+}
+
+func (bundle *Bundle) T(key string, args Args) string {
+	_, message, ok := bundle.lookup(key)
+	if !ok {
+		return key
+	}
+	return Format(message.Forms[Other], args)
+}
+
+func (bundle *Bundle) Plural(key string, count int, args Args) string {
+	locale, message, ok := bundle.lookup(key)
+	if !ok {
+		return key
+	}
+	form := message.form(locale, count)
+	withCount := Args{"count": count}
+	for name, value := range args {
+		withCount[name] = value
+	}
+	return Format(form, withCount)
+}
+
+func (message Message) form(locale string, n int) string {
+	if message.plural != nil {
+		if n < 0 {
+			n = -n
+		}
+		if i := message.plural(n); i >= 0 && i < len(message.indexed) && message.indexed[i] != "" {
+			return message.indexed[i]
+		}
+	}
+	if form, ok := message.Forms[PluralCategory(locale, n)]; ok {
+		return form
+	}
+	return message.Forms[Other]
+}
+
+func Format(str string, args Args) string {
+	if !strings.Contains(str, "{") {
+		return str
+	}
+	out := strings.Builder{}
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		if c == '{' && i+1 < len(str) && str[i+1] == '{' {
+			out.WriteByte('{')
+			i++
+			continue
+		}
+		if c == '}' && i+1 < len(str) && str[i+1] == '}' {
+			out.WriteByte('}')
+			i++
+			continue
+		}
+		if c != '{' {
+			out.WriteByte(c)
+			continue
+		}
+		end := strings.IndexByte(str[i:], '}')
+		if end < 0 {
+			out.WriteString(str[i:])
+			break
+		}
+		name := str[i+1 : i+end]
+		if value, ok := args[name]; ok {
+			fmt.Fprint(&out, value)
+		} else {
+			out.WriteString(str[i : i+end+1])
+		}
+		i += end
+	}
+	return out.String()
+}
+
+func (bundle *Bundle) Bind(target Localizable, key string, args Args) {
+	bundle.bind(&binding{target: target, key: key, args: args})
+}
+
+func (bundle *Bundle) BindPlural(target Localizable, key string, count int, args Args) {
+	bundle.bind(&binding{target: target, key: key, count: count, plural: true, args: args})
+}
+
+func (bundle *Bundle) bind(b *binding) {
+	bundle.Unbind(b.target)
+	bundle.bindings = append(bundle.bindings, b)
+	bundle.apply(b)
+}
+
+func (bundle *Bundle) Unbind(target Localizable) {
+	for i, b := range bundle.bindings {
+		if b.target == target {
+			bundle.bindings = append(bundle.bindings[:i], bundle.bindings[i+1:]...)
+			return
+		}
+	}
+}
+
+func (bundle *Bundle) apply(b *binding) {
+	if b.plural {
+		b.target.SetString(bundle.Plural(b.key, b.count, b.args))
+	} else {
+		b.target.SetString(bundle.T(b.key, b.args))
+	}
+}
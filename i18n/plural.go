@@ -0,0 +1,172 @@
+package i18n
+
+import "strings"
+
+type Category int
+
+const (
+	Zero Category = iota
+	One
+	Two
+	Few
+	Many
+	Other
+)
+
+var categoryNames = []string{"zero", "one", "two", "few", "many", "other"}
+
+func (category Category) String() string {
+	return categoryNames[category]
+}
+
+func ParseCategory(name string) (Category, bool) {
+	for i, categoryName := range categoryNames {
+		if name == categoryName {
+			return Category(i), true
+		}
+	}
+	return Other, false
+}
+
+type pluralRule struct {
+	categories []Category
+	selectFunc func(n int) Category
+}
+
+var (
+	ruleOther = pluralRule{[]Category{Other}, func(n int) Category {
+		return Other
+	}}
+	ruleOne = pluralRule{[]Category{One, Other}, func(n int) Category {
+		if n == 1 {
+			return One
+		}
+		return Other
+	}}
+	ruleZeroOne = pluralRule{[]Category{One, Other}, func(n int) Category {
+		if n == 0 || n == 1 {
+			return One
+		}
+		return Other
+	}}
+	ruleEastSlavic = pluralRule{[]Category{One, Few, Many, Other}, func(n int) Category {
+		switch {
+		case n%10 == 1 && n%100 != 11:
+			return One
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return Few
+		default:
+			return Many
+		}
+	}}
+	rulePolish = pluralRule{[]Category{One, Few, Many, Other}, func(n int) Category {
+		switch {
+		case n == 1:
+			return One
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return Few
+		default:
+			return Many
+		}
+	}}
+	ruleWestSlavic = pluralRule{[]Category{One, Few, Many, Other}, func(n int) Category {
+		switch {
+		case n == 1:
+			return One
+		case n >= 2 && n <= 4:
+			return Few
+		default:
+			return Other
+		}
+	}}
+	ruleSouthSlavic = pluralRule{[]Category{One, Few, Other}, func(n int) Category {
+		switch {
+		case n%10 == 1 && n%100 != 11:
+			return One
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return Few
+		default:
+			return Other
+		}
+	}}
+	ruleRomanian = pluralRule{[]Category{One, Few, Other}, func(n int) Category {
+		switch {
+		case n == 1:
+			return One
+		case n == 0 || (n%100 >= 1 && n%100 <= 19):
+			return Few
+		default:
+			return Other
+		}
+	}}
+	ruleArabic = pluralRule{[]Category{Zero, One, Two, Few, Many, Other}, func(n int) Category {
+		switch {
+		case n == 0:
+			return Zero
+		case n == 1:
+			return One
+		case n == 2:
+			return Two
+		case n%100 >= 3 && n%100 <= 10:
+			return Few
+		case n%100 >= 11 && n%100 <= 99:
+			return Many
+		default:
+			return Other
+		}
+	}}
+	ruleHebrew = pluralRule{[]Category{One, Two, Other}, func(n int) Category {
+		switch {
+		case n == 1:
+			return One
+		case n == 2:
+			return Two
+		default:
+			return Other
+		}
+	}}
+)
+
+var pluralRules = map[string]pluralRule{
+	"ja": ruleOther, "zh": ruleOther, "ko": ruleOther, "th": ruleOther, "vi": ruleOther,
+	"id": ruleOther, "ms": ruleOther, "lo": ruleOther, "my": ruleOther, "km": ruleOther,
+
+	"en": ruleOne, "de": ruleOne, "nl": ruleOne, "sv": ruleOne, "da": ruleOne,
+	"no": ruleOne, "nb": ruleOne, "nn": ruleOne, "fi": ruleOne, "et": ruleOne,
+	"it": ruleOne, "es": ruleOne, "el": ruleOne, "hu": ruleOne, "tr": ruleOne,
+	"bg": ruleOne, "ca": ruleOne, "eu": ruleOne, "gl": ruleOne, "pt-PT": ruleOne,
+
+	"fr": ruleZeroOne, "pt": ruleZeroOne, "hi": ruleZeroOne, "bn": ruleZeroOne, "fa": ruleZeroOne,
+
+	"ru": ruleEastSlavic, "uk": ruleEastSlavic, "be": ruleEastSlavic,
+	"pl": rulePolish,
+	"cs": ruleWestSlavic, "sk": ruleWestSlavic,
+	"hr": ruleSouthSlavic, "sr": ruleSouthSlavic, "bs": ruleSouthSlavic,
+	"ro": ruleRomanian,
+	"ar": ruleArabic,
+	"he": ruleHebrew, "iw": ruleHebrew,
+}
+
+func ruleFor(locale string) pluralRule {
+	locale = strings.Replace(locale, "_", "-", -1)
+	if rule, ok := pluralRules[locale]; ok {
+		return rule
+	}
+	if i := strings.IndexByte(locale, '-'); i >= 0 {
+		if rule, ok := pluralRules[locale[:i]]; ok {
+			return rule
+		}
+	}
+	return ruleOne
+}
+
+func PluralCategory(locale string, n int) Category {
+	if n < 0 {
+		n = -n
+	}
+	return ruleFor(locale).selectFunc(n)
+}
+
+func Categories(locale string) []Category {
+	return ruleFor(locale).categories
+}
@@ -0,0 +1,42 @@
+package i18n
+
+import "testing"
+
+func TestPluralCategory(t *testing.T) {
+	tests := []struct {
+		locale   string
+		n        int
+		category Category
+	}{
+		{"ro", 0, Few},
+		{"ro", 1, One},
+		{"ro", 2, Few},
+		{"ro", 19, Few},
+		{"ro", 20, Other},
+		{"ro", 101, Few},
+		{"ro", 120, Other},
+		{"en", 1, One},
+		{"en", 0, Other},
+		{"en-GB", 2, Other},
+		{"fr", 0, One},
+		{"ru", 21, One},
+		{"ru", 22, Few},
+		{"ru", 11, Many},
+		{"pl", 22, Few},
+		{"pl", 21, Many},
+		{"cs", 3, Few},
+		{"cs", 5, Other},
+		{"ar", 0, Zero},
+		{"ar", 2, Two},
+		{"ar", 103, Few},
+		{"ar", 111, Many},
+		{"ar", 100, Other},
+		{"ja", 1, Other},
+		{"he", -2, Two},
+	}
+	for _, test := range tests {
+		if category := PluralCategory(test.locale, test.n); category != test.category {
+			t.Errorf("PluralCategory(%s, %d) = %v, want %v", test.locale, test.n, category, test.category)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type pluralExpr func(n int) int
+
+type pluralParser struct {
+	tokens []string
+	pos    int
+}
+
+var pluralPrecedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func parsePluralForms(header string) (int, pluralExpr, bool, error) {
+	value := ""
+	found := false
+	for _, line := range strings.Split(header, "\n") {
+		if i := strings.IndexByte(line, ':'); i >= 0 && strings.EqualFold(strings.TrimSpace(line[:i]), "Plural-Forms") {
+			value = line[i+1:]
+			found = true
+			break
+		}
+	}
+	if !found {
+		return 0, nil, false, nil
+	}
+
+	nplurals := 0
+	var expr pluralExpr
+	for _, part := range strings.Split(value, ";") {
+		i := strings.IndexByte(part, '=')
+		if i < 0 {
+			continue
+		}
+		name, rest := strings.TrimSpace(part[:i]), strings.TrimSpace(part[i+1:])
+		switch name {
+		case "nplurals":
+			n, err := strconv.Atoi(rest)
+			if err != nil || n < 1 {
+				return 0, nil, false, fmt.Errorf("invalid nplurals '%s'", rest)
+			}
+			nplurals = n
+		case "plural":
+			var err error
+			expr, err = parsePluralExpr(rest)
+			if err != nil {
+				return 0, nil, false, err
+			}
+		}
+	}
+	if nplurals == 0 || expr == nil {
+		return 0, nil, false, fmt.Errorf("Plural-Forms needs nplurals and plural")
+	}
+	return nplurals, expr, true, nil
+}
+
+func parsePluralExpr(str string) (pluralExpr, error) {
+	tokens, err := tokenizePluralExpr(str)
+	if err != nil {
+		return nil, err
+	}
+	parser := pluralParser{tokens: tokens}
+	expr, err := parser.ternary()
+	if err != nil {
+		return nil, err
+	}
+	if parser.pos < len(parser.tokens) {
+		return nil, fmt.Errorf("unexpected '%s' in plural expression", parser.tokens[parser.pos])
+	}
+	return expr, nil
+}
+
+func tokenizePluralExpr(str string) ([]string, error) {
+	tokens := []string{}
+	for i := 0; i < len(str); {
+		c := str[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c >= '0' && c <= '9':
+			start := i
+			for i < len(str) && str[i] >= '0' && str[i] <= '9' {
+				i++
+			}
+			tokens = append(tokens, str[start:i])
+		case i+1 < len(str) && isPluralOperator(str[i:i+2]):
+			tokens = append(tokens, str[i:i+2])
+			i += 2
+		case strings.IndexByte("n?:()<>!+-*/%", c) >= 0:
+			tokens = append(tokens, string(c))
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected '%c' in plural expression", c)
+		}
+	}
+	return tokens, nil
+}
+
+func isPluralOperator(token string) bool {
+	for _, level := range pluralPrecedence {
+		for _, op := range level {
+			if op == token {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (parser *pluralParser) peek() string {
+	if parser.pos < len(parser.tokens) {
+		return parser.tokens[parser.pos]
+	}
+	return ""
+}
+
+func (parser *pluralParser) accept(token string) bool {
+	if parser.peek() == token {
+		parser.pos++
+		return true
+	}
+	return false
+}
+
+func (parser *pluralParser) ternary() (pluralExpr, error) {
+	condition, err := parser.binary(0)
+	if err != nil {
+		return nil, err
+	}
+	if !parser.accept("?") {
+		return condition, nil
+	}
+	then, err := parser.ternary()
+	if err != nil {
+		return nil, err
+	}
+	if !parser.accept(":") {
+		return nil, fmt.Errorf("expected ':' in plural expression")
+	}
+	otherwise, err := parser.ternary()
+	if err != nil {
+		return nil, err
+	}
+	return func(n int) int {
+		if condition(n) != 0 {
+			return then(n)
+		}
+		return otherwise(n)
+	}, nil
+}
+
+func (parser *pluralParser) binary(level int) (pluralExpr, error) {
+	if level == len(pluralPrecedence) {
+		return parser.unary()
+	}
+	left, err := parser.binary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := parser.peek()
+		matched := false
+		for _, candidate := range pluralPrecedence[level] {
+			matched = matched || op == candidate
+		}
+		if !matched {
+			return left, nil
+		}
+		parser.pos++
+		right, err := parser.binary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = pluralOperator(op, left, right)
+	}
+}
+
+func (parser *pluralParser) unary() (pluralExpr, error) {
+	token := parser.peek()
+	parser.pos++
+	switch {
+	case token == "!":
+		operand, err := parser.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(n int) int { return boolInt(operand(n) == 0) }, nil
+	case token == "(":
+		expr, err := parser.ternary()
+		if err != nil {
+			return nil, err
+		}
+		if !parser.accept(")") {
+			return nil, fmt.Errorf("expected ')' in plural expression")
+		}
+		return expr, nil
+	case token == "n":
+		return func(n int) int { return n }, nil
+	case token != "" && token[0] >= '0' && token[0] <= '9':
+		value, err := strconv.Atoi(token)
+		if err != nil {
+			return nil, err
+		}
+		return func(n int) int { return value }, nil
+	case token == "":
+		return nil, fmt.Errorf("unexpected end of plural expression")
+	}
+	return nil, fmt.Errorf("unexpected '%s' in plural expression", token)
+}
+
+func pluralOperator(op string, left, right pluralExpr) pluralExpr {
+	switch op {
+	case "||":
+		return func(n int) int { return boolInt(left(n) != 0 || right(n) != 0) }
+	case "&&":
+		return func(n int) int { return boolInt(left(n) != 0 && right(n) != 0) }
+	case "==":
+		return func(n int) int { return boolInt(left(n) == right(n)) }
+	case "!=":
+		return func(n int) int { return boolInt(left(n) != right(n)) }
+	case "<":
+		return func(n int) int { return boolInt(left(n) < right(n)) }
+	case "<=":
+		return func(n int) int { return boolInt(left(n) <= right(n)) }
+	case ">":
+		return func(n int) int { return boolInt(left(n) > right(n)) }
+	case ">=":
+		return func(n int) int { return boolInt(left(n) >= right(n)) }
+	case "+":
+		return func(n int) int { return left(n) + right(n) }
+	case "-":
+		return func(n int) int { return left(n) - right(n) }
+	case "*":
+		return func(n int) int { return left(n) * right(n) }
+	case "/":
+		return func(n int) int {
+			if d := right(n); d != 0 {
+				return left(n) / d
+			}
+			return 0
+		}
+	}
+	return func(n int) int {
+		if d := right(n); d != 0 {
+			return left(n) % d
+		}
+		return 0
+	}
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func pluralIndices(locale string, nplurals int, expr pluralExpr) map[Category]int {
+	indices := map[Category]int{}
+	for n := 0; n < 1000; n++ {
+		category := PluralCategory(locale, n)
+		if _, ok := indices[category]; ok {
+			continue
+		}
+		if index := expr(n); index >= 0 && index < nplurals {
+			indices[category] = index
+		}
+	}
+	return indices
+}
@@ -0,0 +1,177 @@
+package i18n
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type poEntry struct {
+	context   string
+	id        string
+	idPlural  string
+	strs      map[int]string
+	fuzzy     bool
+	hasPlural bool
+}
+
+func (bundle *Bundle) LoadPO(locale string, data []byte) error {
+	entries, err := parsePO(data)
+	if err != nil {
+		return fmt.Errorf("i18n %s: %v", locale, err)
+	}
+
+	indices := map[Category]int{}
+	for i, category := range Categories(locale) {
+		indices[category] = i
+	}
+	nplurals := 0
+	var expr pluralExpr
+	for _, entry := range entries {
+		if entry.id != "" || entry.hasPlural {
+			continue
+		}
+		ok := false
+		nplurals, expr, ok, err = parsePluralForms(entry.strs[0])
+		if err != nil {
+			return fmt.Errorf("i18n %s: %v", locale, err)
+		}
+		if ok {
+			indices = pluralIndices(locale, nplurals, expr)
+		}
+		break
+	}
+
+	table := Table{}
+	for _, entry := range entries {
+		if entry.id == "" || entry.fuzzy {
+			continue
+		}
+		message := Message{Forms: map[Category]string{}}
+		if entry.hasPlural {
+			if expr != nil {
+				message.plural = expr
+				message.indexed = make([]string, nplurals)
+				for i := range message.indexed {
+					message.indexed[i] = entry.strs[i]
+				}
+			}
+			for category, i := range indices {
+				if str, ok := entry.strs[i]; ok && str != "" {
+					message.Forms[category] = str
+				}
+			}
+			if _, ok := message.Forms[Other]; !ok {
+				last := ""
+				for i := 0; i < len(entry.strs); i++ {
+					if entry.strs[i] != "" {
+						last = entry.strs[i]
+					}
+				}
+				message.Forms[Other] = last
+			}
+		} else {
+			message.Forms[Other] = entry.strs[0]
+		}
+		if message.Forms[Other] == "" {
+			continue
+		}
+		key := entry.id
+		if entry.context != "" {
+			key = entry.context + "\x04" + entry.id
+		}
+		table[key] = message
+	}
+	bundle.AddTable(locale, table)
+	return nil
+}
+
+func parsePO(data []byte) ([]poEntry, error) {
+	entries := []poEntry{}
+	entry := poEntry{strs: map[int]string{}}
+	var appendTo func(str string)
+	started := false
+
+	flush := func() {
+		if started {
+			entries = append(entries, entry)
+		}
+		entry = poEntry{strs: map[int]string{}}
+		appendTo = nil
+		started = false
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			flush()
+			continue
+		case strings.HasPrefix(line, "#,"):
+			if strings.Contains(line, "fuzzy") {
+				entry.fuzzy = true
+			}
+			continue
+		case strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "\""):
+			if appendTo == nil {
+				return nil, fmt.Errorf("po line %d: unexpected string", lineNumber)
+			}
+			str, err := strconv.Unquote(line)
+			if err != nil {
+				return nil, fmt.Errorf("po line %d: %v", lineNumber, err)
+			}
+			appendTo(str)
+			continue
+		}
+
+		keyword := line
+		rest := ""
+		if i := strings.IndexAny(line, " \t"); i >= 0 {
+			keyword, rest = line[:i], strings.TrimSpace(line[i+1:])
+		}
+		str, err := strconv.Unquote(rest)
+		if err != nil {
+			return nil, fmt.Errorf("po line %d: %v", lineNumber, err)
+		}
+
+		switch {
+		case keyword == "msgctxt":
+			if started && entry.id != "" {
+				flush()
+			}
+			entry.context = str
+			appendTo = func(str string) { entry.context += str }
+		case keyword == "msgid":
+			if started && entry.id != "" {
+				flush()
+			}
+			entry.id = str
+			appendTo = func(str string) { entry.id += str }
+		case keyword == "msgid_plural":
+			entry.idPlural = str
+			entry.hasPlural = true
+			appendTo = func(str string) { entry.idPlural += str }
+		case keyword == "msgstr":
+			entry.strs[0] = str
+			appendTo = func(str string) { entry.strs[0] += str }
+		case strings.HasPrefix(keyword, "msgstr[") && strings.HasSuffix(keyword, "]"):
+			n, err := strconv.Atoi(keyword[len("msgstr[") : len(keyword)-1])
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("po line %d: invalid plural index", lineNumber)
+			}
+			entry.strs[n] = str
+			appendTo = func(str string) { entry.strs[n] += str }
+		default:
+			return nil, fmt.Errorf("po line %d: unknown keyword '%s'", lineNumber, keyword)
+		}
+		started = true
+	}
+	flush()
+
+	return entries, scanner.Err()
+}
@@ -0,0 +1,110 @@
+package i18n
+
+import "testing"
+
+const czechPO = `msgid ""
+msgstr ""
+"Language: cs\n"
+"Plural-Forms: nplurals=3; plural=(n==1) ? 0 : (n>=2 && n<=4) ? 1 : 2;\n"
+
+msgid "file"
+msgid_plural "files"
+msgstr[0] "{count} soubor"
+msgstr[1] "{count} soubory"
+msgstr[2] "{count} souborů"
+`
+
+const russianPO = `msgid ""
+msgstr ""
+"Plural-Forms: nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);\n"
+
+msgid "file"
+msgid_plural "files"
+msgstr[0] "{count} файл"
+msgstr[1] "{count} файла"
+msgstr[2] "{count} файлов"
+`
+
+const hebrewPO = `msgid ""
+msgstr ""
+"Plural-Forms: nplurals=4; plural=(n == 1) ? 0 : ((n == 2) ? 1 : ((n > 10 && n % 10 == 0) ? 2 : 3));\n"
+
+msgid "day"
+msgid_plural "days"
+msgstr[0] "יום"
+msgstr[1] "יומיים"
+msgstr[2] "{count} יום"
+msgstr[3] "{count} ימים"
+`
+
+func TestLoadPOPluralForms(t *testing.T) {
+	tests := []struct {
+		locale string
+		data   string
+		key    string
+		counts map[int]string
+	}{
+		{"cs", czechPO, "file", map[int]string{1: "1 soubor", 3: "3 soubory", 5: "5 souborů", 0: "0 souborů"}},
+		{"he", hebrewPO, "day", map[int]string{1: "יום", 2: "יומיים", 5: "5 ימים", 20: "20 יום", 21: "21 ימים"}},
+		{"ru", russianPO, "file", map[int]string{1: "1 файл", 3: "3 файла", 5: "5 файлов", 11: "11 файлов", 21: "21 файл"}},
+	}
+	for _, test := range tests {
+		bundle := NewBundle(test.locale)
+		if err := bundle.LoadPO(test.locale, []byte(test.data)); err != nil {
+			t.Fatalf("%s: %v", test.locale, err)
+		}
+		for count, want := range test.counts {
+			if got := bundle.Plural(test.key, count, nil); got != want {
+				t.Errorf("%s: Plural(%d) = %q, want %q", test.locale, count, got, want)
+			}
+		}
+	}
+}
+
+func TestParsePluralExpr(t *testing.T) {
+	tests := []struct {
+		expr   string
+		values map[int]int
+	}{
+		{"n != 1", map[int]int{0: 1, 1: 0, 2: 1}},
+		{"n>1", map[int]int{0: 0, 1: 0, 2: 1}},
+		{"n==0 ? 0 : n==1 ? 1 : n==2 ? 2 : n%100>=3 && n%100<=10 ? 3 : n%100>=11 ? 4 : 5", map[int]int{0: 0, 1: 1, 2: 2, 7: 3, 50: 4, 102: 5}},
+		{"!(n%10) + 2 * 3 - 6 / 2", map[int]int{10: 4, 11: 3}},
+	}
+	for _, test := range tests {
+		expr, err := parsePluralExpr(test.expr)
+		if err != nil {
+			t.Errorf("%s: %v", test.expr, err)
+			continue
+		}
+		for n, want := range test.values {
+			if got := expr(n); got != want {
+				t.Errorf("%s with n=%d: got %d, want %d", test.expr, n, got, want)
+			}
+		}
+	}
+
+	for _, expr := range []string{"n ==", "(n == 1", "n ? 1", "n $ 2"} {
+		if _, err := parsePluralExpr(expr); err == nil {
+			t.Errorf("%s: expected an error", expr)
+		}
+	}
+}
+
+func TestLanguageFallbackIsNotMissing(t *testing.T) {
+	bundle := NewBundle("de")
+	bundle.AddTable("en", Table{"hello": {Forms: map[Category]string{Other: "Hello"}}})
+	bundle.AddTable("de", Table{"bye": {Forms: map[Category]string{Other: "Tschüss"}}})
+	bundle.SetLocale("en-US")
+
+	if got := bundle.T("hello", nil); got != "Hello" {
+		t.Errorf("got %q, want Hello", got)
+	}
+	if got := bundle.T("bye", nil); got != "Tschüss" {
+		t.Errorf("got %q, want Tschüss", got)
+	}
+	missing := bundle.Missing()
+	if len(missing) != 1 || missing[0] != (MissingKey{"en-US", "bye"}) {
+		t.Errorf("got missing %v, want only en-US/bye", missing)
+	}
+}
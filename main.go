@@ -68,9 +68,9 @@ func main() {
 
 		transform := aspectRatio.Mul3(mgl32.Translate2D(-0.3, 0)).Mul3(mgl32.Scale2D(0.005, 0.005))
 
-		gl.Clear(gl.COLOR_BUFFER_BIT)
+		gl.Clear(gl.COLOR_BUFFER_BIT | gl.STENCIL_BUFFER_BIT)
 
-		textRenderer.Render(text, transform)
+		textRenderer.Render(&text, transform)
 
 		window.SwapBuffers()
 	}
@@ -0,0 +1,300 @@
+package ui
+
+import (
+	"engine/graphics"
+	"unicode"
+
+	"github.com/go-gl/glfw/v3.2/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+type TextField struct {
+	text           graphics.Text
+	rect           graphics.PathBuffer
+	value          []rune
+	caret          int
+	anchor         int
+	blink          float32
+	Focused        bool
+	MaxLength      int
+	Filter         func(r rune) bool
+	Validate       func(value string) bool
+	OnChange       func(value string)
+	OnSubmit       func(value string)
+	TextColor      mgl32.Vec4
+	CaretColor     mgl32.Vec4
+	SelectionColor mgl32.Vec4
+}
+
+func FilterDigits(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func FilterAlphanumeric(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func FilterPrintable(r rune) bool {
+	return unicode.IsPrint(r)
+}
+
+func NewTextField(font graphics.Font, value string) *TextField {
+	path := graphics.Rect(mgl32.Vec2{0, 0}, mgl32.Vec2{1, 1})
+
+	field := &TextField{
+		text:           graphics.CreateText("", font),
+		rect:           path.ToBuffer(),
+		value:          []rune(value),
+		Focused:        true,
+		Filter:         FilterPrintable,
+		TextColor:      graphics.DefaultTextStyle.Color,
+		CaretColor:     mgl32.Vec4{0, 0, 0, 1},
+		SelectionColor: mgl32.Vec4{0.2, 0.4, 1, 0.4},
+	}
+	field.caret = len(field.value)
+	field.anchor = field.caret
+	field.updateText()
+	return field
+}
+
+func (field *TextField) Value() string {
+	return string(field.value)
+}
+
+func (field *TextField) SetValue(value string) {
+	field.value = []rune(value)
+	field.caret = len(field.value)
+	field.anchor = field.caret
+	field.updateText()
+}
+
+func (field *TextField) Selection() (int, int) {
+	if field.anchor < field.caret {
+		return field.anchor, field.caret
+	}
+	return field.caret, field.anchor
+}
+
+func (field *TextField) SelectedText() string {
+	start, end := field.Selection()
+	return string(field.value[start:end])
+}
+
+func (field *TextField) updateText() {
+	field.text.SetRuns([]graphics.TextRun{{
+		Text:  string(field.value),
+		Style: graphics.TextStyle{Color: field.TextColor},
+	}})
+	field.blink = 0
+}
+
+func (field *TextField) moveCaret(index int, extend bool) {
+	if index < 0 {
+		index = 0
+	}
+	if index > len(field.value) {
+		index = len(field.value)
+	}
+	field.caret = index
+	if !extend {
+		field.anchor = index
+	}
+	field.blink = 0
+}
+
+func (field *TextField) insert(str []rune) bool {
+	filtered := make([]rune, 0, len(str))
+	for _, r := range str {
+		if field.Filter == nil || field.Filter(r) {
+			filtered = append(filtered, r)
+		}
+	}
+
+	start, end := field.Selection()
+	if field.MaxLength > 0 {
+		room := field.MaxLength - (len(field.value) - (end - start))
+		if room < 0 {
+			room = 0
+		}
+		if len(filtered) > room {
+			filtered = filtered[:room]
+		}
+	}
+	if len(filtered) == 0 && (len(str) > 0 || start == end) {
+		return false
+	}
+
+	value := make([]rune, 0, len(field.value)-(end-start)+len(filtered))
+	value = append(value, field.value[:start]...)
+	value = append(value, filtered...)
+	value = append(value, field.value[end:]...)
+	if field.Validate != nil && !field.Validate(string(value)) {
+		return false
+	}
+
+	field.value = value
+	field.moveCaret(start+len(filtered), false)
+	field.changed()
+	return true
+}
+
+func (field *TextField) deleteRange(start, end int) {
+	if start < 0 {
+		start = 0
+	}
+	if end > len(field.value) {
+		end = len(field.value)
+	}
+	if start >= end {
+		return
+	}
+	value := append(append([]rune{}, field.value[:start]...), field.value[end:]...)
+	if field.Validate != nil && !field.Validate(string(value)) {
+		return
+	}
+	field.value = value
+	field.moveCaret(start, false)
+	field.changed()
+}
+
+func (field *TextField) changed() {
+	field.updateText()
+	if field.OnChange != nil {
+		field.OnChange(string(field.value))
+	}
+}
+
+func (field *TextField) CharCallback(window *glfw.Window, char rune) {
+	if !field.Focused {
+		return
+	}
+	field.insert([]rune{char})
+}
+
+func (field *TextField) KeyCallback(window *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+	if !field.Focused || (action != glfw.Press && action != glfw.Repeat) {
+		return
+	}
+
+	shift := mods&glfw.ModShift != 0
+	shortcut := mods&(glfw.ModControl|glfw.ModSuper) != 0
+	start, end := field.Selection()
+
+	switch key {
+	case glfw.KeyLeft:
+		if start != end && !shift {
+			field.moveCaret(start, false)
+		} else if shortcut {
+			field.moveCaret(field.wordBoundary(field.caret, -1), shift)
+		} else {
+			field.moveCaret(field.caret-1, shift)
+		}
+	case glfw.KeyRight:
+		if start != end && !shift {
+			field.moveCaret(end, false)
+		} else if shortcut {
+			field.moveCaret(field.wordBoundary(field.caret, 1), shift)
+		} else {
+			field.moveCaret(field.caret+1, shift)
+		}
+	case glfw.KeyHome:
+		field.moveCaret(0, shift)
+	case glfw.KeyEnd:
+		field.moveCaret(len(field.value), shift)
+	case glfw.KeyBackspace:
+		if start != end {
+			field.deleteRange(start, end)
+		} else if shortcut {
+			field.deleteRange(field.wordBoundary(field.caret, -1), field.caret)
+		} else {
+			field.deleteRange(field.caret-1, field.caret)
+		}
+	case glfw.KeyDelete:
+		if start != end {
+			field.deleteRange(start, end)
+		} else if shortcut {
+			field.deleteRange(field.caret, field.wordBoundary(field.caret, 1))
+		} else {
+			field.deleteRange(field.caret, field.caret+1)
+		}
+	case glfw.KeyA:
+		if shortcut {
+			field.anchor = 0
+			field.moveCaret(len(field.value), true)
+		}
+	case glfw.KeyC, glfw.KeyX:
+		if shortcut && start != end {
+			window.SetClipboardString(field.SelectedText())
+			if key == glfw.KeyX {
+				field.deleteRange(start, end)
+			}
+		}
+	case glfw.KeyV:
+		if shortcut {
+			clipboard, err := window.GetClipboardString()
+			if err == nil {
+				field.insert([]rune(clipboard))
+			}
+		}
+	case glfw.KeyEnter, glfw.KeyKPEnter:
+		if field.OnSubmit != nil {
+			field.OnSubmit(string(field.value))
+		}
+	}
+}
+
+func (field *TextField) wordBoundary(index, direction int) int {
+	isWord := func(i int) bool {
+		return unicode.IsLetter(field.value[i]) || unicode.IsDigit(field.value[i])
+	}
+	if direction < 0 {
+		for index > 0 && !isWord(index-1) {
+			index--
+		}
+		for index > 0 && isWord(index-1) {
+			index--
+		}
+		return index
+	}
+	for index < len(field.value) && !isWord(index) {
+		index++
+	}
+	for index < len(field.value) && isWord(index) {
+		index++
+	}
+	return index
+}
+
+func (field *TextField) Click(point mgl32.Vec2, extend bool) {
+	field.moveCaret(field.text.Layout().HitTest(point), extend)
+}
+
+func (field *TextField) Update(dt float32) {
+	field.blink += dt
+}
+
+func (field *TextField) Render(textRenderer *graphics.TextRenderer, pathRenderer *graphics.PathRenderer, transform mgl32.Mat3) {
+	layout := field.text.Layout()
+	bottom := layout.Min.Y()
+	height := layout.Max.Y() - layout.Min.Y()
+
+	start, end := field.Selection()
+	if start != end {
+		left := layout.CaretPosition(start).X()
+		right := layout.CaretPosition(end).X()
+		pathRenderer.Fill(field.rect, transform.Mul3(mgl32.Translate2D(left, bottom)).Mul3(mgl32.Scale2D(right-left, height)), graphics.SolidPaint(field.SelectionColor), graphics.FillStyle{})
+	}
+
+	textRenderer.Render(&field.text, transform)
+
+	if field.Focused && int(field.blink*2)%2 == 0 {
+		x := layout.CaretPosition(field.caret).X()
+		width := height * 0.05
+		pathRenderer.Fill(field.rect, transform.Mul3(mgl32.Translate2D(x-width/2, bottom)).Mul3(mgl32.Scale2D(width, height)), graphics.SolidPaint(field.CaretColor), graphics.FillStyle{})
+	}
+}
+
+func (field *TextField) Delete() {
+	field.text.Delete()
+	field.rect.Delete()
+}
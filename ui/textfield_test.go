@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/go-gl/glfw/v3.2/glfw"
+)
+
+func testField(value string) *TextField {
+	field := &TextField{value: []rune(value), Focused: true, Filter: FilterPrintable}
+	field.caret = len(field.value)
+	field.anchor = field.caret
+	return field
+}
+
+func press(field *TextField, key glfw.Key, mods glfw.ModifierKey) {
+	field.KeyCallback(nil, key, 0, glfw.Press, mods)
+}
+
+func checkField(t *testing.T, name string, field *TextField, value string, start, end int) {
+	t.Helper()
+	selStart, selEnd := field.Selection()
+	if field.Value() != value || selStart != start || selEnd != end {
+		t.Errorf("%s: got %q [%d,%d], want %q [%d,%d]", name, field.Value(), selStart, selEnd, value, start, end)
+	}
+}
+
+func TestTextFieldCaretAndSelection(t *testing.T) {
+	field := testField("hello world")
+	press(field, glfw.KeyLeft, 0)
+	checkField(t, "left", field, "hello world", 10, 10)
+	press(field, glfw.KeyLeft, glfw.ModShift)
+	press(field, glfw.KeyLeft, glfw.ModShift)
+	checkField(t, "shift left", field, "hello world", 8, 10)
+	if field.SelectedText() != "rl" {
+		t.Errorf("got selected text %q, want \"rl\"", field.SelectedText())
+	}
+	press(field, glfw.KeyRight, 0)
+	checkField(t, "right collapses to the end", field, "hello world", 10, 10)
+	press(field, glfw.KeyHome, glfw.ModShift)
+	checkField(t, "shift home", field, "hello world", 0, 10)
+	press(field, glfw.KeyLeft, 0)
+	checkField(t, "left collapses to the start", field, "hello world", 0, 0)
+	press(field, glfw.KeyLeft, 0)
+	checkField(t, "left at the start", field, "hello world", 0, 0)
+	press(field, glfw.KeyEnd, 0)
+	press(field, glfw.KeyRight, 0)
+	checkField(t, "right at the end", field, "hello world", 11, 11)
+	press(field, glfw.KeyA, glfw.ModControl)
+	checkField(t, "select all", field, "hello world", 0, 11)
+}
+
+func TestTextFieldWordJumps(t *testing.T) {
+	field := testField("one, two  three")
+	press(field, glfw.KeyLeft, glfw.ModControl)
+	checkField(t, "word left", field, "one, two  three", 10, 10)
+	press(field, glfw.KeyLeft, glfw.ModControl)
+	checkField(t, "word left over spaces", field, "one, two  three", 5, 5)
+	press(field, glfw.KeyLeft, glfw.ModControl|glfw.ModShift)
+	checkField(t, "word left over punctuation", field, "one, two  three", 0, 5)
+	press(field, glfw.KeyRight, glfw.ModControl)
+	checkField(t, "word right collapses the selection", field, "one, two  three", 5, 5)
+	press(field, glfw.KeyRight, glfw.ModControl)
+	checkField(t, "word right", field, "one, two  three", 8, 8)
+
+	press(field, glfw.KeyBackspace, glfw.ModControl)
+	checkField(t, "delete word left", field, "one,   three", 5, 5)
+	press(field, glfw.KeyDelete, glfw.ModControl)
+	checkField(t, "delete word right", field, "one, ", 5, 5)
+	press(field, glfw.KeyBackspace, 0)
+	checkField(t, "backspace", field, "one,", 4, 4)
+}
+
+func TestTextFieldInsert(t *testing.T) {
+	changes := 0
+	field := testField("ab")
+	field.OnChange = func(string) { changes++ }
+	field.CharCallback(nil, 'c')
+	checkField(t, "typed", field, "abc", 3, 3)
+
+	field.Filter = FilterDigits
+	field.CharCallback(nil, 'x')
+	checkField(t, "filtered", field, "abc", 3, 3)
+
+	field.anchor = 0
+	field.CharCallback(nil, 'x')
+	checkField(t, "filtered over a selection", field, "abc", 0, 3)
+	field.insert([]rune("x1y2"))
+	checkField(t, "partly filtered over a selection", field, "12", 2, 2)
+	if changes != 2 {
+		t.Errorf("got %d changes, want 2", changes)
+	}
+
+	field.MaxLength = 4
+	field.insert([]rune("345"))
+	checkField(t, "max length", field, "1234", 4, 4)
+	field.CharCallback(nil, '6')
+	checkField(t, "full", field, "1234", 4, 4)
+	field.anchor = 2
+	field.insert([]rune("789"))
+	checkField(t, "max length over a selection", field, "1278", 4, 4)
+
+	field.Validate = func(value string) bool { return value != "127" }
+	press(field, glfw.KeyBackspace, 0)
+	checkField(t, "validated", field, "1278", 4, 4)
+
+	field.Focused = false
+	field.CharCallback(nil, '9')
+	checkField(t, "unfocused", field, "1278", 4, 4)
+}